@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+	"github.com/codecrafters-io/redis-starter-go/app/parser"
+)
+
+func init() {
+	register(&Spec{
+		Name:       APPEND,
+		Arity:      3,
+		Flags:      []string{FlagWrite, FlagDenyOOM, FlagFast},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &AppendCommand{args[0], args[1]}, nil },
+		Summary:    "Appends a string to the value of a key. Creates the key if it doesn't exist.",
+		Since:      "2.0.0",
+		Group:      GroupString,
+		Complexity: "O(1). The amortized time complexity is O(1) assuming the appended value is small and the already present value is of any size, since the dynamic string library used by Redis will double the free space available on every reallocation.",
+	})
+}
+
+type AppendCommand struct {
+	Key   string
+	Value string
+}
+
+func (cmd *AppendCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var n int
+
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		v, _, err := getString(tx, cmd.Key)
+		if err != nil {
+			return err
+		}
+
+		if err := checkStringLength(int64(len(v.Value)), len(cmd.Value)); err != nil {
+			return err
+		}
+
+		v.Value += cmd.Value
+		n = len(v.Value)
+		tx.Put(cmd.Key, v)
+		inst.Propagate([]string{"APPEND", cmd.Key, cmd.Value})
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.Integer(int64(n))
+	return nil
+}
+
+// checkStringLength rejects writing n bytes at offset, if the resulting
+// string would be larger than a client may send.
+func checkStringLength(offset int64, n int) error {
+	if offset > int64(parser.MaxBulkLen)-int64(n) {
+		return errorf("string exceeds maximum allowed size (proto-max-bulk-len)")
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:     BLMOVE,
+		Arity:    6,
+		Flags:    []string{FlagWrite, FlagDenyOOM, FlagBlocking},
+		FirstKey: 1,
+		LastKey:  2,
+		Step:     1,
+		Create: func(args []string) (Command, error) {
+			from, err := parseDirection(args[2])
+			if err != nil {
+				return nil, err
+			}
+
+			to, err := parseDirection(args[3])
+			if err != nil {
+				return nil, err
+			}
+
+			timeout, err := parseTimeout(args[4])
+			if err != nil {
+				return nil, err
+			}
+
+			cmd := &BlmoveCommand{LmoveCommand: LmoveCommand{args[0], args[1], from, to}}
+			cmd.Timeout = timeout
+			return cmd, nil
+		},
+		Summary:    "Pops an element from a list, pushes it to another list and returns it. Blocks until an element is available otherwise. Deletes the list if the last element was moved.",
+		Since:      "6.2.0",
+		Group:      GroupList,
+		Complexity: "O(1)",
+	})
+	register(&Spec{
+		Name:     BRPOPLPUSH,
+		Arity:    4,
+		Flags:    []string{FlagWrite, FlagDenyOOM, FlagBlocking},
+		FirstKey: 1,
+		LastKey:  2,
+		Step:     1,
+		Create: func(args []string) (Command, error) {
+			timeout, err := parseTimeout(args[2])
+			if err != nil {
+				return nil, err
+			}
+
+			cmd := &BlmoveCommand{LmoveCommand: LmoveCommand{args[0], args[1], false, true}}
+			cmd.Timeout = timeout
+			return cmd, nil
+		},
+		Summary:    "Pops an element from a list, pushes it to another list and returns it. Block until an element is available otherwise. Deletes the list if the last element was popped.",
+		Since:      "2.2.0",
+		Group:      GroupList,
+		Complexity: "O(1)",
+	})
+}
+
+// BlmoveCommand implements BLMOVE and BRPOPLPUSH, the blocking variants of
+// LMOVE and RPOPLPUSH.
+type BlmoveCommand struct {
+	Blocking
+	LmoveCommand
+}
+
+func (cmd *BlmoveCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var elem string
+
+	serve := func(string) (bool, error) {
+		moved := false
+		err := inst.Store.Update([]string{cmd.Src, cmd.Dst}, func(tx *instance.Tx) error {
+			var err error
+			elem, moved, err = moveElement(tx, cmd.Src, cmd.Dst, cmd.FromLeft, cmd.ToLeft)
+			if moved {
+				inst.Propagate([]string{"LMOVE", cmd.Src, cmd.Dst, direction(cmd.FromLeft), direction(cmd.ToLeft)})
+			}
+			return err
+		})
+
+		if err != nil || !moved {
+			return false, err
+		}
+
+		inst.SignalReady(cmd.Dst)
+		return true, nil
+	}
+
+	waiter, err := inst.Block([]string{cmd.Src}, instance.KindList, serve)
+	if err != nil {
+		return err
+	}
+
+	if waiter != nil {
+		served, err := waiter.Wait(cmd.Timeout, cmd.Cancel)
+		if err != nil {
+			return err
+		}
+
+		if !served {
+			w.NullBulk()
+			return nil
+		}
+	}
+
+	w.Bulk(elem)
+	return nil
+}
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:  BLMPOP,
+		Arity: -5,
+		Flags: []string{FlagWrite, FlagBlocking},
+		GetKeys: func(args []string) []string {
+			return numKeys(args[1:])
+		},
+		Create: func(args []string) (Command, error) {
+			timeout, err := parseTimeout(args[0])
+			if err != nil {
+				return nil, err
+			}
+
+			lmpop, err := NewLmpopCommand(args[1:])
+			if err != nil {
+				return nil, err
+			}
+
+			cmd := &BlmpopCommand{LmpopCommand: *lmpop}
+			cmd.Timeout = timeout
+			return cmd, nil
+		},
+		Summary:    "Pops the first element from one of multiple lists. Blocks until an element is available otherwise. Deletes the list if the last element was popped.",
+		Since:      "7.0.0",
+		Group:      GroupList,
+		Complexity: "O(N+M) where N is the number of provided keys and M is the number of elements returned.",
+	})
+}
+
+// BlmpopCommand implements BLMPOP, the blocking variant of LMPOP.
+type BlmpopCommand struct {
+	Blocking
+	LmpopCommand
+}
+
+func (cmd *BlmpopCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var key string
+	var elems []string
+
+	serve := func(k string) (bool, error) {
+		err := inst.Store.Update([]string{k}, func(tx *instance.Tx) error {
+			var err error
+			key, elems, err = popFirstList(tx, []string{k}, cmd.Left, cmd.Count)
+			if elems != nil {
+				propagatePop(inst, key, cmd.Left, len(elems))
+			}
+			return err
+		})
+
+		if err != nil || elems == nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	waiter, err := inst.Block(cmd.Keys, instance.KindList, serve)
+	if err != nil {
+		return err
+	}
+
+	if waiter != nil {
+		served, err := waiter.Wait(cmd.Timeout, cmd.Cancel)
+		if err != nil {
+			return err
+		}
+
+		if !served {
+			w.NullArray()
+			return nil
+		}
+	}
+
+	w.ArrayHeader(2)
+	w.Bulk(key)
+	w.Array(elems)
+	return nil
+}
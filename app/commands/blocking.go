@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// Blocking holds the timeout of a blocking command, a Timeout of 0 blocks
+// forever. It implements SetCancel of BlockingCommand.
+type Blocking struct {
+	Timeout time.Duration
+	Cancel  <-chan struct{}
+}
+
+func (b *Blocking) SetCancel(cancel <-chan struct{}) {
+	b.Cancel = cancel
+}
+
+// parseTimeout parses the timeout of a blocking command, given in seconds
+// with an optional fraction.
+func parseTimeout(arg string) (time.Duration, error) {
+	secs, err := strconv.ParseFloat(arg, 64)
+	if err != nil || math.IsNaN(secs) || math.IsInf(secs, 0) {
+		return 0, ErrTimeout
+	}
+
+	if secs < 0 {
+		return 0, ErrNegativeTTL
+	}
+
+	if secs*float64(time.Second) > math.MaxInt64 {
+		return 0, ErrTimeout
+	}
+
+	return time.Duration(secs * float64(time.Second)), nil
+}
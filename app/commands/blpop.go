@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	bpop := func(name string, summary string) *Spec {
+		return &Spec{
+			Name:     name,
+			Arity:    -3,
+			Flags:    []string{FlagWrite, FlagBlocking},
+			FirstKey: 1,
+			LastKey:  -2,
+			Step:     1,
+			Create: func(args []string) (Command, error) {
+				timeout, err := parseTimeout(args[len(args)-1])
+				if err != nil {
+					return nil, err
+				}
+
+				cmd := &BpopCommand{Keys: args[:len(args)-1], Left: name == BLPOP}
+				cmd.Timeout = timeout
+				return cmd, nil
+			},
+			Summary:    summary,
+			Since:      "2.0.0",
+			Group:      GroupList,
+			Complexity: "O(N) where N is the number of provided keys.",
+		}
+	}
+
+	register(bpop(BLPOP, "Removes and returns the first element in a list. Blocks until an element is available otherwise. Deletes the list if the last element was popped."))
+	register(bpop(BRPOP, "Removes and returns the last element in a list. Blocks until an element is available otherwise. Deletes the list if the last element was popped."))
+}
+
+// BpopCommand implements BLPOP and BRPOP. The element is popped from the
+// first non-empty list, if all are empty the client blocks until one of
+// them gets an element.
+type BpopCommand struct {
+	Blocking
+	Keys []string
+	Left bool
+}
+
+func (cmd *BpopCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var key string
+	var elems []string
+
+	// Blocked clients may be served by another client, replicas get the
+	// pop that took place
+	serve := func(k string) (bool, error) {
+		err := inst.Store.Update([]string{k}, func(tx *instance.Tx) error {
+			var err error
+			key, elems, err = popFirstList(tx, []string{k}, cmd.Left, 1)
+			if elems == nil {
+				return err
+			}
+
+			if cmd.Left {
+				inst.Propagate([]string{"LPOP", key})
+			} else {
+				inst.Propagate([]string{"RPOP", key})
+			}
+			return nil
+		})
+
+		if err != nil || elems == nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	waiter, err := inst.Block(cmd.Keys, instance.KindList, serve)
+	if err != nil {
+		return err
+	}
+
+	if waiter != nil {
+		served, err := waiter.Wait(cmd.Timeout, cmd.Cancel)
+		if err != nil {
+			return err
+		}
+
+		if !served {
+			w.NullArray()
+			return nil
+		}
+	}
+
+	w.Array([]string{key, elems[0]})
+	return nil
+}
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:  BZMPOP,
+		Arity: -5,
+		Flags: []string{FlagWrite, FlagBlocking},
+		GetKeys: func(args []string) []string {
+			return numKeys(args[1:])
+		},
+		Create: func(args []string) (Command, error) {
+			timeout, err := parseTimeout(args[0])
+			if err != nil {
+				return nil, err
+			}
+
+			zmpop, err := NewZmpopCommand(args[1:])
+			if err != nil {
+				return nil, err
+			}
+
+			cmd := &BzmpopCommand{ZmpopCommand: *zmpop}
+			cmd.Timeout = timeout
+			return cmd, nil
+		},
+		Summary:    "Removes and returns a member by score from one or more sorted sets. Blocks until a member is available otherwise. Deletes the sorted set if the last element was popped.",
+		Since:      "7.0.0",
+		Group:      GroupSortedSet,
+		Complexity: "O(K) + O(M*log(N)) where K is the number of provided keys, N being the number of elements in the sorted set, and M being the number of elements popped.",
+	})
+}
+
+// BzmpopCommand implements BZMPOP, the blocking variant of ZMPOP.
+type BzmpopCommand struct {
+	Blocking
+	ZmpopCommand
+}
+
+func (cmd *BzmpopCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var key string
+	var items []scored
+
+	serve := func(k string) (bool, error) {
+		err := inst.Store.Update([]string{k}, func(tx *instance.Tx) error {
+			var err error
+			key, items, err = popFirstZSet(tx, []string{k}, cmd.Max, cmd.Count)
+			if items != nil {
+				propagateZpop(inst, key, cmd.Max, len(items))
+			}
+			return err
+		})
+
+		if err != nil || items == nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	waiter, err := inst.Block(cmd.Keys, instance.KindZSet, serve)
+	if err != nil {
+		return err
+	}
+
+	if waiter != nil {
+		served, err := waiter.Wait(cmd.Timeout, cmd.Cancel)
+		if err != nil {
+			return err
+		}
+
+		if !served {
+			w.NullArray()
+			return nil
+		}
+	}
+
+	writeZmpop(w, key, items)
+	return nil
+}
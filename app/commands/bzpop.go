@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	bzpop := func(name string, summary string) *Spec {
+		return &Spec{
+			Name:     name,
+			Arity:    -3,
+			Flags:    []string{FlagWrite, FlagFast, FlagBlocking},
+			FirstKey: 1,
+			LastKey:  -2,
+			Step:     1,
+			Create: func(args []string) (Command, error) {
+				timeout, err := parseTimeout(args[len(args)-1])
+				if err != nil {
+					return nil, err
+				}
+
+				cmd := &BzpopCommand{Keys: args[:len(args)-1], Max: name == BZPOPMAX}
+				cmd.Timeout = timeout
+				return cmd, nil
+			},
+			Summary:    summary,
+			Since:      "5.0.0",
+			Group:      GroupSortedSet,
+			Complexity: "O(log(N)) with N being the number of elements in the sorted set.",
+		}
+	}
+
+	register(bzpop(BZPOPMIN, "Removes and returns the member with the lowest score from one or more sorted sets. Blocks until a member is available otherwise. Deletes the sorted set if the last element was popped."))
+	register(bzpop(BZPOPMAX, "Removes and returns the member with the highest score from one or more sorted sets. Blocks until a member is available otherwise. Deletes the sorted set if the last element was popped."))
+}
+
+// BzpopCommand implements BZPOPMIN and BZPOPMAX. Like BLPOP, the member is
+// popped from the first non-empty sorted set, if all are empty the client
+// blocks until one of them gets a member.
+type BzpopCommand struct {
+	Blocking
+	Keys []string
+	Max  bool
+}
+
+func (cmd *BzpopCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var key string
+	var items []scored
+
+	serve := func(k string) (bool, error) {
+		err := inst.Store.Update([]string{k}, func(tx *instance.Tx) error {
+			var err error
+			key, items, err = popFirstZSet(tx, []string{k}, cmd.Max, 1)
+			if items != nil {
+				propagateZpop(inst, key, cmd.Max, 1)
+			}
+			return err
+		})
+
+		if err != nil || items == nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	waiter, err := inst.Block(cmd.Keys, instance.KindZSet, serve)
+	if err != nil {
+		return err
+	}
+
+	if waiter != nil {
+		served, err := waiter.Wait(cmd.Timeout, cmd.Cancel)
+		if err != nil {
+			return err
+		}
+
+		if !served {
+			w.NullArray()
+			return nil
+		}
+	}
+
+	w.ArrayHeader(3)
+	w.Bulk(key)
+	w.Bulk(items[0].Member)
+	w.Double(items[0].Score)
+	return nil
+}
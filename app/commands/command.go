@@ -2,9 +2,9 @@ package commands
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
 	"github.com/codecrafters-io/redis-starter-go/app/instance"
 )
 
@@ -16,62 +16,175 @@ const (
 	INFO     = "info"
 	REPLCONF = "replconf"
 	PSYNC    = "psync"
-	ERROR    = "error"
-	OK       = "ok"
-)
+	HELLO    = "hello"
+	WAIT     = "wait"
+	COMMAND  = "command"
+	DEL      = "del"
+	UNLINK   = "unlink"
+	EXISTS   = "exists"
+	TYPE     = "type"
+	RENAME   = "rename"
+	RENAMENX = "renamenx"
+	COPY     = "copy"
 
-type Command interface {
-	Execute(inst *instance.Instance) ([]byte, error)
-}
+	EXPIRE      = "expire"
+	PEXPIRE     = "pexpire"
+	EXPIREAT    = "expireat"
+	PEXPIREAT   = "pexpireat"
+	TTL         = "ttl"
+	PTTL        = "pttl"
+	EXPIRETIME  = "expiretime"
+	PEXPIRETIME = "pexpiretime"
+	PERSIST     = "persist"
 
-type ErrorCommand struct {
-	Msg string
-}
+	INCR        = "incr"
+	DECR        = "decr"
+	INCRBY      = "incrby"
+	DECRBY      = "decrby"
+	INCRBYFLOAT = "incrbyfloat"
 
-func (cmd *ErrorCommand) Execute(inst *instance.Instance) ([]byte, error) {
-	fmt.Printf("Error encountered: %s\n", cmd.Msg)
-	return nil, nil
-}
+	APPEND   = "append"
+	STRLEN   = "strlen"
+	GETRANGE = "getrange"
+	SETRANGE = "setrange"
+	GETDEL   = "getdel"
+	GETEX    = "getex"
+	GETSET   = "getset"
 
-type OkCommand struct{}
+	MSET   = "mset"
+	MSETNX = "msetnx"
+	MGET   = "mget"
 
-func (cmd *OkCommand) Execute(inst *instance.Instance) ([]byte, error) {
-	return nil, nil
-}
+	LPUSH     = "lpush"
+	RPUSH     = "rpush"
+	LPUSHX    = "lpushx"
+	RPUSHX    = "rpushx"
+	LPOP      = "lpop"
+	RPOP      = "rpop"
+	LRANGE    = "lrange"
+	LLEN      = "llen"
+	LINDEX    = "lindex"
+	LSET      = "lset"
+	LREM      = "lrem"
+	LTRIM     = "ltrim"
+	LINSERT   = "linsert"
+	LPOS      = "lpos"
+	LMOVE     = "lmove"
+	RPOPLPUSH = "rpoplpush"
+	LMPOP     = "lmpop"
 
-type PongCommand struct{}
+	BLPOP      = "blpop"
+	BRPOP      = "brpop"
+	BLMOVE     = "blmove"
+	BRPOPLPUSH = "brpoplpush"
+	BLMPOP     = "blmpop"
 
-func (cmd *PongCommand) Execute(inst *instance.Instance) ([]byte, error) {
-	return nil, nil
-}
+	HSET         = "hset"
+	HMSET        = "hmset"
+	HSETNX       = "hsetnx"
+	HGET         = "hget"
+	HMGET        = "hmget"
+	HDEL         = "hdel"
+	HGETALL      = "hgetall"
+	HKEYS        = "hkeys"
+	HVALS        = "hvals"
+	HLEN         = "hlen"
+	HEXISTS      = "hexists"
+	HINCRBY      = "hincrby"
+	HINCRBYFLOAT = "hincrbyfloat"
+	HSTRLEN      = "hstrlen"
+	HRANDFIELD   = "hrandfield"
+	HEXPIRE      = "hexpire"
+	HPEXPIRE     = "hpexpire"
+	HEXPIREAT    = "hexpireat"
+	HPEXPIREAT   = "hpexpireat"
+	HTTL         = "httl"
+	HPTTL        = "hpttl"
+	HEXPIRETIME  = "hexpiretime"
+	HPEXPIRETIME = "hpexpiretime"
+	HPERSIST     = "hpersist"
+
+	SADD        = "sadd"
+	SREM        = "srem"
+	SMEMBERS    = "smembers"
+	SISMEMBER   = "sismember"
+	SMISMEMBER  = "smismember"
+	SCARD       = "scard"
+	SPOP        = "spop"
+	SRANDMEMBER = "srandmember"
+	SMOVE       = "smove"
+	SINTER      = "sinter"
+	SINTERSTORE = "sinterstore"
+	SINTERCARD  = "sintercard"
+	SUNION      = "sunion"
+	SUNIONSTORE = "sunionstore"
+	SDIFF       = "sdiff"
+	SDIFFSTORE  = "sdiffstore"
 
-type FullsyncCommand struct{}
+	ZADD             = "zadd"
+	ZINCRBY          = "zincrby"
+	ZREM             = "zrem"
+	ZCARD            = "zcard"
+	ZSCORE           = "zscore"
+	ZMSCORE          = "zmscore"
+	ZRANK            = "zrank"
+	ZREVRANK         = "zrevrank"
+	ZCOUNT           = "zcount"
+	ZLEXCOUNT        = "zlexcount"
+	ZRANGE           = "zrange"
+	ZRANGESTORE      = "zrangestore"
+	ZREVRANGE        = "zrevrange"
+	ZRANGEBYSCORE    = "zrangebyscore"
+	ZREVRANGEBYSCORE = "zrevrangebyscore"
+	ZRANGEBYLEX      = "zrangebylex"
+	ZREVRANGEBYLEX   = "zrevrangebylex"
+	ZREMRANGEBYRANK  = "zremrangebyrank"
+	ZREMRANGEBYSCORE = "zremrangebyscore"
+	ZREMRANGEBYLEX   = "zremrangebylex"
+	ZPOPMIN          = "zpopmin"
+	ZPOPMAX          = "zpopmax"
+	ZUNION           = "zunion"
+	ZUNIONSTORE      = "zunionstore"
+	ZINTER           = "zinter"
+	ZINTERSTORE      = "zinterstore"
+	ZDIFF            = "zdiff"
+	ZDIFFSTORE       = "zdiffstore"
+	ZMPOP            = "zmpop"
+	BZPOPMIN         = "bzpopmin"
+	BZPOPMAX         = "bzpopmax"
+	BZMPOP           = "bzmpop"
+)
+
+// Command is a parsed command ready to run. Execute writes the reply to w,
+// commands without a reply write nothing.
+type Command interface {
+	Execute(inst *instance.Instance, w *encode.Writer) error
+}
 
-func (cmd *FullsyncCommand) Execute(inst *instance.Instance) ([]byte, error) {
-	return nil, nil
+// BlockingCommand is a command that may block the client, such as BLPOP.
+// The server passes a channel that is closed once the client disconnects,
+// which unblocks the command.
+type BlockingCommand interface {
+	Command
+	SetCancel(cancel <-chan struct{})
 }
 
-func CreateCommand(t string, args []string) Command {
-	if t == "ping" {
-		return &PingCommand{}
-	} else if t == "pong" {
-		return &PongCommand{}
-	} else if t == "echo" {
-		return &EchoCommand{args[0]}
-	} else if t == "info" {
-		return &InfoCommand{strings.ToLower(args[0])}
-	} else if t == "get" {
-		return &GetCommand{args[0]}
-	} else if t == "set" {
-		return &SetCommand{args[0], args[1], args[2:]}
-	} else if t == "replconf" {
-		return &ReplconfCommand{strings.ToLower(args[0]), strings.ToLower(args[1])}
-	} else if t == "psync" {
-		return &PsyncCommand{}
-	} else if t == "wait" {
-		replCnt, _ := strconv.Atoi(args[0])
-		timeout, _ := strconv.Atoi(args[1])
-		return &WaitCommand{replCnt, timeout}
+// CreateCommand looks up the command called name and parses its arguments.
+// Unknown commands and a wrong number of arguments are reported as error.
+func CreateCommand(name string, args []string) (Command, error) {
+	spec, ok := Lookup(name)
+
+	if !ok {
+		var quoted strings.Builder
+		for _, arg := range args {
+			fmt.Fprintf(&quoted, "'%s' ", arg)
+		}
+		return nil, errorf("unknown command '%s', with args beginning with: %s", name, quoted.String())
+	}
+
+	if !spec.CheckArity(len(args) + 1) {
+		return nil, errorf("wrong number of arguments for '%s' command", spec.Name)
 	}
-	return nil
+
+	return spec.Create(args)
 }
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+	"github.com/codecrafters-io/redis-starter-go/app/parser"
+)
+
+// execute runs a command against inst and returns the parsed reply. Errors
+// are returned as error replies, like the server sends them.
+func execute(t *testing.T, inst *instance.Instance, proto int, args ...string) parser.Value {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := encode.NewWriter(&buf)
+	w.Proto = proto
+
+	cmd, err := CreateCommand(args[0], args[1:])
+	if err == nil {
+		err = cmd.Execute(inst, w)
+	}
+	if err != nil {
+		WriteError(w, err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := parser.NewReader(&buf)
+	v, err := r.ReadValue()
+	if err != nil {
+		t.Fatalf("%q: invalid reply: %v", args, err)
+	}
+	if r.Buffered() != 0 {
+		t.Fatalf("%q: %d bytes left after the reply", args, r.Buffered())
+	}
+	return v
+}
+
+// failingWriter fails every write, like a connection closed by the client.
+type failingWriter struct{}
+
+var errClosed = errors.New("connection closed")
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errClosed
+}
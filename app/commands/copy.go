@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       COPY,
+		Arity:      -3,
+		Flags:      []string{FlagWrite, FlagDenyOOM},
+		FirstKey:   1,
+		LastKey:    2,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return NewCopyCommand(args) },
+		Summary:    "Copies the value of a key to a new key.",
+		Since:      "6.2.0",
+		Group:      GroupGeneric,
+		Complexity: "O(N) worst case for collections, where N is the number of nested items. O(1) for string values.",
+	})
+}
+
+// CopyCommand implements COPY source destination [DB destination-db]
+// [REPLACE]. There is only database 0.
+type CopyCommand struct {
+	Src     string
+	Dst     string
+	Replace bool
+}
+
+func NewCopyCommand(args []string) (*CopyCommand, error) {
+	cmd := &CopyCommand{Src: args[0], Dst: args[1]}
+
+	for i := 2; i < len(args); i++ {
+		switch strings.ToLower(args[i]) {
+		case "replace":
+			cmd.Replace = true
+		case "db":
+			if i+1 >= len(args) {
+				return nil, ErrSyntax
+			}
+			if args[i+1] != "0" {
+				return nil, errorf("DB index is out of range")
+			}
+			i++
+		default:
+			return nil, ErrSyntax
+		}
+	}
+
+	return cmd, nil
+}
+
+func (cmd *CopyCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	if cmd.Src == cmd.Dst {
+		return errorf("source and destination objects are the same")
+	}
+
+	copied := false
+
+	inst.Store.Update([]string{cmd.Src, cmd.Dst}, func(tx *instance.Tx) error {
+		if copied = tx.Copy(cmd.Src, cmd.Dst, cmd.Replace); !copied {
+			return nil
+		}
+
+		args := []string{"COPY", cmd.Src, cmd.Dst}
+		if cmd.Replace {
+			args = append(args, "REPLACE")
+		}
+		inst.Propagate(args)
+		return nil
+	})
+
+	if !copied {
+		w.Integer(0)
+		return nil
+	}
+
+	inst.SignalReady(cmd.Dst)
+
+	w.Integer(1)
+	return nil
+}
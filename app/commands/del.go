@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       DEL,
+		Arity:      -2,
+		Flags:      []string{FlagWrite},
+		FirstKey:   1,
+		LastKey:    -1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &DelCommand{"DEL", args}, nil },
+		Summary:    "Deletes one or more keys.",
+		Since:      "1.0.0",
+		Group:      GroupGeneric,
+		Complexity: "O(N) where N is the number of keys that will be removed.",
+	})
+	register(&Spec{
+		Name:       UNLINK,
+		Arity:      -2,
+		Flags:      []string{FlagWrite, FlagFast},
+		FirstKey:   1,
+		LastKey:    -1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &DelCommand{"UNLINK", args}, nil },
+		Summary:    "Asynchronously deletes one or more keys.",
+		Since:      "4.0.0",
+		Group:      GroupGeneric,
+		Complexity: "O(1) for each key removed regardless of its size.",
+	})
+}
+
+// DelCommand implements DEL and UNLINK. Memory is reclaimed by the garbage
+// collector either way, so there is no difference between the two.
+type DelCommand struct {
+	Name string
+	Keys []string
+}
+
+func (cmd *DelCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	n := 0
+
+	inst.Store.Update(cmd.Keys, func(tx *instance.Tx) error {
+		for _, key := range cmd.Keys {
+			if tx.Delete(key) {
+				n++
+			}
+		}
+
+		if n > 0 {
+			inst.Propagate(append([]string{cmd.Name}, cmd.Keys...))
+		}
+		return nil
+	})
+
+	w.Integer(int64(n))
+	return nil
+}
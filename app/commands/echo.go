@@ -1,14 +1,28 @@
 package commands
 
 import (
-	"github.com/codecrafters-io/redis-starter-go/app/client"
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
 	"github.com/codecrafters-io/redis-starter-go/app/instance"
 )
 
+func init() {
+	register(&Spec{
+		Name:       ECHO,
+		Arity:      2,
+		Flags:      []string{FlagFast},
+		Create:     func(args []string) (Command, error) { return &EchoCommand{args[0]}, nil },
+		Summary:    "Returns the given string.",
+		Since:      "1.0.0",
+		Group:      GroupConnection,
+		Complexity: "O(1)",
+	})
+}
+
 type EchoCommand struct {
 	Payload string
 }
 
-func (cmd *EchoCommand) Execute(inst *instance.Instance) ([]byte, error) {
-	return client.EncodeBulk(cmd.Payload), nil
+func (cmd *EchoCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	w.Bulk(cmd.Payload)
+	return nil
 }
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+)
+
+// Error prefixes, clients use them to tell errors apart
+const (
+	PrefixErr       = "ERR"
+	PrefixWrongType = "WRONGTYPE"
+	PrefixNoProto   = "NOPROTO"
+)
+
+// Error is returned by commands for failures that are replied to the client
+// as is. Other errors are replied with the generic ERR prefix.
+type Error struct {
+	Prefix string
+	Msg    string
+}
+
+func (e *Error) Error() string {
+	return e.Prefix + " " + e.Msg
+}
+
+var (
+	ErrSyntax      = &Error{PrefixErr, "syntax error"}
+	ErrNotInteger  = &Error{PrefixErr, "value is not an integer or out of range"}
+	ErrNotFloat    = &Error{PrefixErr, "value is not a valid float"}
+	ErrWrongType   = &Error{PrefixWrongType, "Operation against a key holding the wrong kind of value"}
+	ErrNoProto     = &Error{PrefixNoProto, "unsupported protocol version"}
+	ErrTimeout     = &Error{PrefixErr, "timeout is not a float or out of range"}
+	ErrNegativeTTL = &Error{PrefixErr, "timeout is negative"}
+)
+
+// errorf creates an error with the ERR prefix.
+func errorf(format string, args ...any) *Error {
+	return &Error{PrefixErr, fmt.Sprintf(format, args...)}
+}
+
+// WriteError writes err as error reply.
+func WriteError(w *encode.Writer, err error) {
+	var e *Error
+	if errors.As(err, &e) {
+		w.Error(e.Error())
+	} else {
+		w.Error(PrefixErr + " " + err.Error())
+	}
+}
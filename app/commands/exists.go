@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       EXISTS,
+		Arity:      -2,
+		Flags:      []string{FlagReadonly, FlagFast},
+		FirstKey:   1,
+		LastKey:    -1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &ExistsCommand{args}, nil },
+		Summary:    "Determines whether one or more keys exist.",
+		Since:      "1.0.0",
+		Group:      GroupGeneric,
+		Complexity: "O(N) where N is the number of keys to check.",
+	})
+}
+
+type ExistsCommand struct {
+	Keys []string
+}
+
+func (cmd *ExistsCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	// Keys given multiple times are counted multiple times
+	n := 0
+	for _, key := range cmd.Keys {
+		if inst.Store.Contains(key) {
+			n++
+		}
+	}
+
+	w.Integer(int64(n))
+	return nil
+}
@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+var errInvalidExpire = errors.New("invalid expire time")
+
+func init() {
+	expire := func(name string, summary string, since string) *Spec {
+		return &Spec{
+			Name:       name,
+			Arity:      -3,
+			Flags:      []string{FlagWrite, FlagFast},
+			FirstKey:   1,
+			LastKey:    1,
+			Step:       1,
+			Create:     func(args []string) (Command, error) { return NewExpireCommand(name, args, time.Now()) },
+			Summary:    summary,
+			Since:      since,
+			Group:      GroupGeneric,
+			Complexity: "O(1)",
+		}
+	}
+
+	register(expire(EXPIRE, "Sets the expiration time of a key in seconds.", "1.0.0"))
+	register(expire(PEXPIRE, "Sets the expiration time of a key in milliseconds.", "2.6.0"))
+	register(expire(EXPIREAT, "Sets the expiration time of a key to a Unix timestamp.", "1.2.0"))
+	register(expire(PEXPIREAT, "Sets the expiration time of a key to a Unix milliseconds timestamp.", "2.6.0"))
+}
+
+// ExpireCommand implements EXPIRE, PEXPIRE, EXPIREAT and PEXPIREAT, all of
+// them are converted to an absolute point in time when parsed.
+type ExpireCommand struct {
+	Name     string
+	Key      string
+	ExpireAt time.Time
+	Cond     instance.ExpireCondition
+}
+
+func NewExpireCommand(name string, args []string, now time.Time) (*ExpireCommand, error) {
+	cmd := &ExpireCommand{Name: name, Key: args[0]}
+
+	n, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return nil, ErrNotInteger
+	}
+
+	ms, ok := toUnixMilli(name, n, now)
+	if !ok {
+		return nil, errorf("invalid expire time in '%s' command", name)
+	}
+	cmd.ExpireAt = time.UnixMilli(ms)
+
+	for _, arg := range args[2:] {
+		switch strings.ToLower(arg) {
+		case "nx":
+			cmd.Cond |= instance.ExpireNX
+		case "xx":
+			cmd.Cond |= instance.ExpireXX
+		case "gt":
+			cmd.Cond |= instance.ExpireGT
+		case "lt":
+			cmd.Cond |= instance.ExpireLT
+		default:
+			return nil, errorf("Unsupported option %s", arg)
+		}
+	}
+
+	if cmd.Cond&instance.ExpireNX != 0 && cmd.Cond != instance.ExpireNX {
+		return nil, errorf("NX and XX, GT or LT options at the same time are not compatible")
+	}
+
+	if cmd.Cond&instance.ExpireGT != 0 && cmd.Cond&instance.ExpireLT != 0 {
+		return nil, errorf("GT and LT options at the same time are not compatible")
+	}
+
+	return cmd, nil
+}
+
+// toUnixMilli converts the time argument of the given command to a Unix
+// timestamp in milliseconds. It fails if the result overflows.
+func toUnixMilli(name string, n int64, now time.Time) (int64, bool) {
+	if name == EXPIRE || name == EXPIREAT || name == "ex" || name == "exat" {
+		if n > math.MaxInt64/1000 || n < math.MinInt64/1000 {
+			return 0, false
+		}
+		n *= 1000
+	}
+
+	if name == EXPIRE || name == PEXPIRE || name == "ex" || name == "px" {
+		if n > math.MaxInt64-now.UnixMilli() {
+			return 0, false
+		}
+		n += now.UnixMilli()
+	}
+
+	return n, true
+}
+
+func (cmd *ExpireCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	changed := false
+
+	inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		if _, changed = tx.Expire(cmd.Key, cmd.ExpireAt, cmd.Cond); !changed {
+			return nil
+		}
+
+		// Replicas get the absolute time, so they expire the key at the same
+		// time no matter when they receive the command
+		if cmd.ExpireAt.After(tx.Now()) {
+			inst.Propagate([]string{"PEXPIREAT", cmd.Key, strconv.FormatInt(cmd.ExpireAt.UnixMilli(), 10)})
+		} else {
+			inst.Propagate([]string{"DEL", cmd.Key})
+		}
+		return nil
+	})
+
+	if !changed {
+		w.Integer(0)
+	} else {
+		w.Integer(1)
+	}
+	return nil
+}
+
+// parseExpireTime converts the argument of the EX, PX, EXAT or PXAT option to
+// an absolute point in time. Relative times are added to now.
+func parseExpireTime(unit string, arg string, now time.Time) (time.Time, error) {
+	n, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return time.Time{}, ErrNotInteger
+	}
+
+	if n <= 0 {
+		return time.Time{}, errInvalidExpire
+	}
+
+	ms, ok := toUnixMilli(unit, n, now)
+	if !ok {
+		return time.Time{}, errInvalidExpire
+	}
+
+	return time.UnixMilli(ms), nil
+}
@@ -2,20 +2,53 @@ package commands
 
 import (
 	"fmt"
-	"github.com/codecrafters-io/redis-starter-go/app/client"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
 	"github.com/codecrafters-io/redis-starter-go/app/instance"
 )
 
+func init() {
+	register(&Spec{
+		Name:       GET,
+		Arity:      2,
+		Flags:      []string{FlagReadonly, FlagFast},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &GetCommand{args[0]}, nil },
+		Summary:    "Returns the string value of a key.",
+		Since:      "1.0.0",
+		Group:      GroupString,
+		Complexity: "O(1)",
+	})
+}
+
 type GetCommand struct {
 	Key string
 }
 
-func (cmd *GetCommand) Execute(inst *instance.Instance) ([]byte, error) {
-	val, ok := inst.Store.Read(cmd.Key)
+func (cmd *GetCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	v, ok := inst.Store.Get(cmd.Key)
+	if ok && v.Kind != instance.KindString {
+		return ErrWrongType
+	}
+
 	if !ok {
 		fmt.Printf("Debug: get %s, not in store\n", cmd.Key)
-		return []byte("$-1\r\n"), nil
+		w.NullBulk()
+		return nil
+	}
+	fmt.Printf("Debug: get %s = %s\n", cmd.Key, v.Value)
+	w.Bulk(v.Value)
+	return nil
+}
+
+// getString returns the string stored at key. Keys holding another type are
+// an error.
+func getString(tx *instance.Tx, key string) (instance.Value, bool, error) {
+	v, ok := tx.Get(key)
+	if ok && v.Kind != instance.KindString {
+		return instance.Value{}, false, ErrWrongType
 	}
-	fmt.Printf("Debug: get %s = %s\n", cmd.Key, val)
-	return client.EncodeBulk(val), nil
+	return v, ok, nil
 }
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       GETDEL,
+		Arity:      2,
+		Flags:      []string{FlagWrite, FlagFast},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &GetdelCommand{args[0]}, nil },
+		Summary:    "Returns the string value of a key after deleting the key.",
+		Since:      "6.2.0",
+		Group:      GroupString,
+		Complexity: "O(1)",
+	})
+}
+
+type GetdelCommand struct {
+	Key string
+}
+
+func (cmd *GetdelCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var v instance.Value
+	var ok bool
+
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		var err error
+		if v, ok, err = getString(tx, cmd.Key); ok {
+			tx.Delete(cmd.Key)
+			inst.Propagate([]string{"DEL", cmd.Key})
+		}
+		return err
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		w.NullBulk()
+		return nil
+	}
+
+	w.Bulk(v.Value)
+	return nil
+}
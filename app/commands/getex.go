@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       GETEX,
+		Arity:      -2,
+		Flags:      []string{FlagWrite, FlagFast},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return NewGetexCommand(args) },
+		Summary:    "Returns the string value of a key after setting its expiration time.",
+		Since:      "6.2.0",
+		Group:      GroupString,
+		Complexity: "O(1)",
+	})
+}
+
+// GetexCommand implements GETEX key [EX seconds | PX milliseconds |
+// EXAT unix-time-seconds | PXAT unix-time-milliseconds | PERSIST]. Without
+// options it behaves like GET.
+type GetexCommand struct {
+	Key      string
+	ExpireAt time.Time
+	Persist  bool
+}
+
+func NewGetexCommand(args []string) (*GetexCommand, error) {
+	cmd := &GetexCommand{Key: args[0]}
+
+	for i := 1; i < len(args); i++ {
+		opt := strings.ToLower(args[i])
+
+		switch opt {
+		case "persist":
+			if !cmd.ExpireAt.IsZero() {
+				return nil, ErrSyntax
+			}
+			cmd.Persist = true
+		case "ex", "px", "exat", "pxat":
+			if !cmd.ExpireAt.IsZero() || cmd.Persist || i+1 >= len(args) {
+				return nil, ErrSyntax
+			}
+
+			expireAt, err := parseExpireTime(opt, args[i+1], time.Now())
+			if err != nil {
+				if err == errInvalidExpire {
+					return nil, errorf("invalid expire time in 'getex' command")
+				}
+				return nil, err
+			}
+
+			cmd.ExpireAt = expireAt
+			i++
+		default:
+			return nil, ErrSyntax
+		}
+	}
+
+	return cmd, nil
+}
+
+func (cmd *GetexCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var v instance.Value
+	var ok bool
+
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		var err error
+		if v, ok, err = getString(tx, cmd.Key); !ok {
+			return err
+		}
+
+		var propagated []string
+		switch {
+		case !cmd.ExpireAt.IsZero() && !cmd.ExpireAt.After(tx.Now()):
+			tx.Delete(cmd.Key)
+			propagated = []string{"DEL", cmd.Key}
+		case !cmd.ExpireAt.IsZero():
+			tx.Put(cmd.Key, instance.Value{Value: v.Value, ExpireAt: cmd.ExpireAt})
+			propagated = []string{"PEXPIREAT", cmd.Key, strconv.FormatInt(cmd.ExpireAt.UnixMilli(), 10)}
+		case cmd.Persist && v.HasExpiry():
+			tx.Put(cmd.Key, instance.Value{Value: v.Value})
+			propagated = []string{"PERSIST", cmd.Key}
+		}
+
+		if propagated != nil {
+			inst.Propagate(propagated)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		w.NullBulk()
+		return nil
+	}
+
+	w.Bulk(v.Value)
+	return nil
+}
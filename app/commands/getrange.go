@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"strconv"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:     GETRANGE,
+		Arity:    4,
+		Flags:    []string{FlagReadonly},
+		FirstKey: 1,
+		LastKey:  1,
+		Step:     1,
+		Create: func(args []string) (Command, error) {
+			start, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return nil, ErrNotInteger
+			}
+
+			end, err := strconv.ParseInt(args[2], 10, 64)
+			if err != nil {
+				return nil, ErrNotInteger
+			}
+
+			return &GetrangeCommand{args[0], start, end}, nil
+		},
+		Summary:    "Returns a substring of the string stored at a key.",
+		Since:      "2.4.0",
+		Group:      GroupString,
+		Complexity: "O(N) where N is the length of the returned string. The complexity is ultimately determined by the returned length, but because creating a substring from an existing string is very cheap, it can be considered O(1) for small strings.",
+	})
+}
+
+// GetrangeCommand implements GETRANGE. Negative offsets count from the end
+// of the string, both offsets are inclusive.
+type GetrangeCommand struct {
+	Key   string
+	Start int64
+	End   int64
+}
+
+func (cmd *GetrangeCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	v, ok := inst.Store.Get(cmd.Key)
+	if ok && v.Kind != instance.KindString {
+		return ErrWrongType
+	}
+
+	str := v.Value
+	n := int64(len(str))
+
+	start, end := cmd.Start, cmd.End
+	if start < 0 && end < 0 && start > end {
+		w.Bulk("")
+		return nil
+	}
+
+	if start < 0 {
+		start = max(n+start, 0)
+	}
+	if end < 0 {
+		end = max(n+end, 0)
+	}
+	end = min(end, n-1)
+
+	if start > end || n == 0 {
+		w.Bulk("")
+		return nil
+	}
+
+	w.Bulk(str[start : end+1])
+	return nil
+}
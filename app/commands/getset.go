@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       GETSET,
+		Arity:      3,
+		Flags:      []string{FlagWrite, FlagDenyOOM, FlagFast},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &GetsetCommand{args[0], args[1]}, nil },
+		Summary:    "Returns the previous string value of a key after setting it to a new value.",
+		Since:      "1.0.0",
+		Group:      GroupString,
+		Complexity: "O(1)",
+	})
+}
+
+// GetsetCommand implements GETSET, which behaves like SET with the GET
+// option and discards the time to live of the key.
+type GetsetCommand struct {
+	Key   string
+	Value string
+}
+
+func (cmd *GetsetCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var prev instance.Value
+	var existed bool
+
+	inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		var written bool
+		prev, existed, written = tx.Set(cmd.Key, cmd.Value, instance.SetOptions{Get: true})
+		if written {
+			inst.Propagate([]string{"SET", cmd.Key, cmd.Value})
+		}
+		return nil
+	})
+
+	if existed && prev.Kind != instance.KindString {
+		return ErrWrongType
+	}
+
+	if existed {
+		w.Bulk(prev.Value)
+	} else {
+		w.NullBulk()
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+// getHash returns the hash stored at key, or nil if the key does not exist.
+// Keys holding another type are an error.
+func getHash(tx *instance.Tx, key string) (*instance.Hash, error) {
+	v, ok := tx.Get(key)
+	if !ok {
+		return nil, nil
+	}
+
+	if v.Kind != instance.KindHash {
+		return nil, ErrWrongType
+	}
+	return v.Hash, nil
+}
+
+// getOrCreateHash returns the hash stored at key, creating an empty one if
+// the key does not exist.
+func getOrCreateHash(tx *instance.Tx, key string) (*instance.Hash, error) {
+	h, err := getHash(tx, key)
+	if h != nil || err != nil {
+		return h, err
+	}
+
+	h = instance.NewHash()
+	tx.Put(key, instance.Value{Kind: instance.KindHash, Hash: h})
+	return h, nil
+}
+
+// viewHash runs fn with the hash stored at key, which is nil if the key does
+// not exist.
+func viewHash(inst *instance.Instance, key string, fn func(h *instance.Hash)) error {
+	return inst.Store.View([]string{key}, func(tx *instance.Tx) error {
+		h, err := getHash(tx, key)
+		if err != nil {
+			return err
+		}
+
+		fn(h)
+		return nil
+	})
+}
+
+// parseFields parses FIELDS numfields field [field ...], which ends the
+// arguments of the commands dealing with the expiry of hash fields.
+func parseFields(args []string) ([]string, error) {
+	if len(args) < 2 || !strings.EqualFold(args[0], "FIELDS") {
+		return nil, errorf("Mandatory argument FIELDS is missing or not at the right position")
+	}
+
+	n, err := strconv.Atoi(args[1])
+	if err != nil || n <= 0 {
+		return nil, errorf("Parameter `numFields` should be greater than 0")
+	}
+
+	if n != len(args)-2 {
+		return nil, errorf("The `numfields` parameter must match the number of arguments")
+	}
+	return args[2:], nil
+}
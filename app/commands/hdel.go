@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       HDEL,
+		Arity:      -3,
+		Flags:      []string{FlagWrite, FlagFast},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &HdelCommand{args[0], args[1:]}, nil },
+		Summary:    "Deletes one or more fields and their values from a hash. Deletes the hash if no fields remain.",
+		Since:      "2.0.0",
+		Group:      GroupHash,
+		Complexity: "O(N) where N is the number of fields to be removed.",
+	})
+}
+
+type HdelCommand struct {
+	Key    string
+	Fields []string
+}
+
+func (cmd *HdelCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	n := 0
+
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		h, err := getHash(tx, cmd.Key)
+		if h == nil {
+			return err
+		}
+
+		for _, field := range cmd.Fields {
+			if h.Delete(field) {
+				n++
+			}
+		}
+
+		if h.Len() == 0 {
+			tx.Delete(cmd.Key)
+		}
+
+		if n > 0 {
+			inst.Propagate(append([]string{"HDEL", cmd.Key}, cmd.Fields...))
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.Integer(int64(n))
+	return nil
+}
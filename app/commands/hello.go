@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       HELLO,
+		Arity:      -1,
+		Flags:      []string{FlagNoScript, FlagLoading, FlagStale, FlagFast, FlagNoAuth},
+		Create:     func(args []string) (Command, error) { return NewHelloCommand(args) },
+		Summary:    "Handshakes with the Redis server.",
+		Since:      "6.0.0",
+		Group:      GroupConnection,
+		Complexity: "O(1)",
+	})
+}
+
+type HelloCommand struct {
+	Proto    int
+	ClientID int64
+	Name     string
+}
+
+func (cmd *HelloCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	// The reply already uses the new protocol
+	if cmd.Proto != 0 {
+		w.Proto = cmd.Proto
+	}
+
+	role := inst.Role()
+	if role == "slave" {
+		role = "replica"
+	}
+
+	w.MapHeader(7)
+	w.Bulk("server")
+	w.Bulk("redis")
+	w.Bulk("version")
+	w.Bulk("7.2.0")
+	w.Bulk("proto")
+	w.Integer(int64(w.Proto))
+	w.Bulk("id")
+	w.Integer(cmd.ClientID)
+	w.Bulk("mode")
+	w.Bulk("standalone")
+	w.Bulk("role")
+	w.Bulk(role)
+	w.Bulk("modules")
+	w.ArrayHeader(0)
+
+	return nil
+}
+
+// NewHelloCommand parses HELLO [protover [AUTH username password] [SETNAME clientname]].
+// A protocol version of 0 means the client keeps its current version.
+func NewHelloCommand(args []string) (*HelloCommand, error) {
+	cmd := &HelloCommand{}
+
+	if len(args) == 0 {
+		return cmd, nil
+	}
+
+	if args[0] != "2" && args[0] != "3" {
+		return nil, ErrNoProto
+	}
+	cmd.Proto = int(args[0][0] - '0')
+
+	for i := 1; i < len(args); i++ {
+		switch strings.ToLower(args[i]) {
+		case "auth":
+			// There is only the default user without a password, so any
+			// credentials are accepted.
+			if i+2 >= len(args) {
+				return nil, ErrSyntax
+			}
+			i += 2
+		case "setname":
+			if i+1 >= len(args) {
+				return nil, ErrSyntax
+			}
+			cmd.Name = args[i+1]
+			i++
+		default:
+			return nil, ErrSyntax
+		}
+	}
+
+	return cmd, nil
+}
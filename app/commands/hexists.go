@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       HEXISTS,
+		Arity:      3,
+		Flags:      []string{FlagReadonly, FlagFast},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &HexistsCommand{args[0], args[1]}, nil },
+		Summary:    "Determines whether a field exists in a hash.",
+		Since:      "2.0.0",
+		Group:      GroupHash,
+		Complexity: "O(1)",
+	})
+}
+
+type HexistsCommand struct {
+	Key   string
+	Field string
+}
+
+func (cmd *HexistsCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	exists := false
+
+	err := viewHash(inst, cmd.Key, func(h *instance.Hash) {
+		if h != nil {
+			_, exists = h.Get(cmd.Field)
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		w.Integer(1)
+	} else {
+		w.Integer(0)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+// hashMaxExpireMilli is the latest expiry of a hash field in Unix
+// milliseconds, the same limit Redis has.
+const hashMaxExpireMilli = 1<<48 - 1
+
+func init() {
+	hexpire := func(name string, unit string, summary string) *Spec {
+		return &Spec{
+			Name:       name,
+			Arity:      -6,
+			Flags:      []string{FlagWrite, FlagFast},
+			FirstKey:   1,
+			LastKey:    1,
+			Step:       1,
+			Create:     func(args []string) (Command, error) { return NewHexpireCommand(name, unit, args, time.Now()) },
+			Summary:    summary,
+			Since:      "7.4.0",
+			Group:      GroupHash,
+			Complexity: "O(N) where N is the number of specified fields",
+		}
+	}
+
+	register(hexpire(HEXPIRE, "ex", "Set expiry for hash field using relative time to expire (seconds)"))
+	register(hexpire(HPEXPIRE, "px", "Set expiry for hash field using relative time to expire (milliseconds)"))
+	register(hexpire(HEXPIREAT, "exat", "Set expiry for hash field using an absolute Unix timestamp (seconds)"))
+	register(hexpire(HPEXPIREAT, "pxat", "Set expiry for hash field using an absolute Unix timestamp (milliseconds)"))
+}
+
+// HexpireCommand implements HEXPIRE, HPEXPIRE, HEXPIREAT and HPEXPIREAT. Like
+// EXPIRE, the time is converted to an absolute point in time when parsed.
+type HexpireCommand struct {
+	Key      string
+	ExpireAt time.Time
+	Cond     instance.ExpireCondition
+	Fields   []string
+}
+
+// NewHexpireCommand parses the arguments of the command name, whose time
+// argument is in the given unit, which is one of the SET options.
+func NewHexpireCommand(name string, unit string, args []string, now time.Time) (*HexpireCommand, error) {
+	cmd := &HexpireCommand{Key: args[0]}
+
+	n, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return nil, ErrNotInteger
+	}
+	if n < 0 {
+		return nil, errorf("invalid expire time, must be >= 0")
+	}
+
+	ms, ok := toUnixMilli(unit, n, now)
+	if !ok || ms > hashMaxExpireMilli {
+		return nil, errorf("invalid expire time in '%s' command", name)
+	}
+	cmd.ExpireAt = time.UnixMilli(ms)
+
+	rest := args[2:]
+	switch strings.ToLower(rest[0]) {
+	case "nx":
+		cmd.Cond = instance.ExpireNX
+	case "xx":
+		cmd.Cond = instance.ExpireXX
+	case "gt":
+		cmd.Cond = instance.ExpireGT
+	case "lt":
+		cmd.Cond = instance.ExpireLT
+	}
+	if cmd.Cond != 0 {
+		rest = rest[1:]
+	}
+
+	if cmd.Fields, err = parseFields(rest); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+// Execute replies with a code for each field: -2 if it does not exist, 0 if
+// the condition was not met, 1 if the expiry was set and 2 if the field was
+// deleted because the time has passed already.
+func (cmd *HexpireCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	codes := make([]int64, len(cmd.Fields))
+
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		h, err := getHash(tx, cmd.Key)
+		if err != nil {
+			return err
+		}
+
+		var set, deleted []string
+		for i, field := range cmd.Fields {
+			if h == nil {
+				codes[i] = -2
+				continue
+			}
+
+			if _, exists := h.Get(field); !exists {
+				codes[i] = -2
+				continue
+			}
+
+			if !h.Expire(field, cmd.ExpireAt, cmd.Cond) {
+				continue
+			}
+
+			if cmd.ExpireAt.After(tx.Now()) {
+				codes[i] = 1
+				set = append(set, field)
+			} else {
+				h.Delete(field)
+				codes[i] = 2
+				deleted = append(deleted, field)
+			}
+		}
+
+		switch {
+		case h == nil:
+		case h.Len() == 0:
+			tx.Delete(cmd.Key)
+		case len(set) > 0:
+			// Put the value again, so the key is tracked as expiring
+			v, _ := tx.Get(cmd.Key)
+			tx.Put(cmd.Key, v)
+		}
+
+		// Replicas get the absolute time, as for EXPIRE
+		if len(set) > 0 {
+			args := []string{"HPEXPIREAT", cmd.Key, strconv.FormatInt(cmd.ExpireAt.UnixMilli(), 10), "FIELDS", strconv.Itoa(len(set))}
+			inst.Propagate(append(args, set...))
+		}
+		if len(deleted) > 0 {
+			inst.Propagate(append([]string{"HDEL", cmd.Key}, deleted...))
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.ArrayHeader(len(codes))
+	for _, code := range codes {
+		w.Integer(code)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       HGET,
+		Arity:      3,
+		Flags:      []string{FlagReadonly, FlagFast},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &HgetCommand{args[0], args[1]}, nil },
+		Summary:    "Returns the value of a field in a hash.",
+		Since:      "2.0.0",
+		Group:      GroupHash,
+		Complexity: "O(1)",
+	})
+	register(&Spec{
+		Name:       HMGET,
+		Arity:      -3,
+		Flags:      []string{FlagReadonly, FlagFast},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &HmgetCommand{args[0], args[1:]}, nil },
+		Summary:    "Returns the values of all specified fields in a hash.",
+		Since:      "2.0.0",
+		Group:      GroupHash,
+		Complexity: "O(N) where N is the number of fields being requested.",
+	})
+}
+
+type HgetCommand struct {
+	Key   string
+	Field string
+}
+
+func (cmd *HgetCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var value string
+	var ok bool
+
+	err := viewHash(inst, cmd.Key, func(h *instance.Hash) {
+		if h != nil {
+			value, ok = h.Get(cmd.Field)
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		w.Bulk(value)
+	} else {
+		w.NullBulk()
+	}
+	return nil
+}
+
+type HmgetCommand struct {
+	Key    string
+	Fields []string
+}
+
+func (cmd *HmgetCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	values := make([]string, len(cmd.Fields))
+	found := make([]bool, len(cmd.Fields))
+
+	err := viewHash(inst, cmd.Key, func(h *instance.Hash) {
+		if h == nil {
+			return
+		}
+
+		for i, field := range cmd.Fields {
+			values[i], found[i] = h.Get(field)
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.ArrayHeader(len(cmd.Fields))
+	for i, value := range values {
+		if found[i] {
+			w.Bulk(value)
+		} else {
+			w.NullBulk()
+		}
+	}
+	return nil
+}
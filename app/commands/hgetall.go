@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	hgetall := func(name string, summary string) *Spec {
+		return &Spec{
+			Name:     name,
+			Arity:    2,
+			Flags:    []string{FlagReadonly},
+			FirstKey: 1,
+			LastKey:  1,
+			Step:     1,
+			Create: func(args []string) (Command, error) {
+				return &HgetallCommand{Key: args[0], Fields: name != HVALS, Values: name != HKEYS}, nil
+			},
+			Summary:    summary,
+			Since:      "2.0.0",
+			Group:      GroupHash,
+			Complexity: "O(N) where N is the size of the hash.",
+		}
+	}
+
+	register(hgetall(HGETALL, "Returns all fields and values in a hash."))
+	register(hgetall(HKEYS, "Returns all fields in a hash."))
+	register(hgetall(HVALS, "Returns all values in a hash."))
+}
+
+// HgetallCommand implements HGETALL, HKEYS and HVALS. HGETALL replies with
+// a map, the others with an array.
+type HgetallCommand struct {
+	Key    string
+	Fields bool
+	Values bool
+}
+
+func (cmd *HgetallCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var items []string
+
+	err := viewHash(inst, cmd.Key, func(h *instance.Hash) {
+		if h == nil {
+			return
+		}
+
+		h.Range(func(field string, value string) bool {
+			if cmd.Fields {
+				items = append(items, field)
+			}
+			if cmd.Values {
+				items = append(items, value)
+			}
+			return true
+		})
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if !cmd.Fields || !cmd.Values {
+		w.Array(items)
+		return nil
+	}
+
+	w.MapHeader(len(items) / 2)
+	for _, item := range items {
+		w.Bulk(item)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:     HINCRBY,
+		Arity:    4,
+		Flags:    []string{FlagWrite, FlagDenyOOM, FlagFast},
+		FirstKey: 1,
+		LastKey:  1,
+		Step:     1,
+		Create: func(args []string) (Command, error) {
+			delta, err := strconv.ParseInt(args[2], 10, 64)
+			if err != nil {
+				return nil, ErrNotInteger
+			}
+			return &HincrbyCommand{args[0], args[1], delta}, nil
+		},
+		Summary:    "Increments the integer value of a field in a hash by a number. Uses 0 as initial value if the field doesn't exist.",
+		Since:      "2.0.0",
+		Group:      GroupHash,
+		Complexity: "O(1)",
+	})
+	register(&Spec{
+		Name:     HINCRBYFLOAT,
+		Arity:    4,
+		Flags:    []string{FlagWrite, FlagDenyOOM, FlagFast},
+		FirstKey: 1,
+		LastKey:  1,
+		Step:     1,
+		Create: func(args []string) (Command, error) {
+			delta, err := strconv.ParseFloat(args[2], 64)
+			if err != nil || math.IsNaN(delta) {
+				return nil, ErrNotFloat
+			}
+			return &HincrbyfloatCommand{args[0], args[1], delta}, nil
+		},
+		Summary:    "Increments the floating point value of a field by a number. Uses 0 as initial value if the field doesn't exist.",
+		Since:      "2.6.0",
+		Group:      GroupHash,
+		Complexity: "O(1)",
+	})
+}
+
+type HincrbyCommand struct {
+	Key   string
+	Field string
+	Delta int64
+}
+
+func (cmd *HincrbyCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var n int64
+
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		h, err := getHash(tx, cmd.Key)
+		if err != nil {
+			return err
+		}
+
+		if h != nil {
+			if value, ok := h.Get(cmd.Field); ok {
+				if n, err = strconv.ParseInt(value, 10, 64); err != nil {
+					return errorf("hash value is not an integer")
+				}
+			}
+		}
+
+		if (cmd.Delta > 0 && n > math.MaxInt64-cmd.Delta) || (cmd.Delta < 0 && n < math.MinInt64-cmd.Delta) {
+			return errorf("increment or decrement would overflow")
+		}
+
+		// The hash is only created once the increment is known to succeed
+		if h == nil {
+			h, _ = getOrCreateHash(tx, cmd.Key)
+		}
+
+		n += cmd.Delta
+		h.SetKeepTTL(cmd.Field, strconv.FormatInt(n, 10))
+		inst.Propagate([]string{"HINCRBY", cmd.Key, cmd.Field, strconv.FormatInt(cmd.Delta, 10)})
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.Integer(n)
+	return nil
+}
+
+// HincrbyfloatCommand implements HINCRBYFLOAT. Like INCRBYFLOAT, the result
+// is propagated instead of the increment.
+type HincrbyfloatCommand struct {
+	Key   string
+	Field string
+	Delta float64
+}
+
+func (cmd *HincrbyfloatCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var str string
+
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		h, err := getHash(tx, cmd.Key)
+		if err != nil {
+			return err
+		}
+
+		f := 0.0
+		if h != nil {
+			if value, ok := h.Get(cmd.Field); ok {
+				if f, err = strconv.ParseFloat(value, 64); err != nil || math.IsNaN(f) {
+					return errorf("hash value is not a float")
+				}
+			}
+		}
+
+		f += cmd.Delta
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return errorf("increment would produce NaN or Infinity")
+		}
+
+		if h == nil {
+			h, _ = getOrCreateHash(tx, cmd.Key)
+		}
+
+		str = strconv.FormatFloat(f, 'f', -1, 64)
+		h.SetKeepTTL(cmd.Field, str)
+
+		// HSET removes the expiry of the field, so it is sent again
+		inst.Propagate([]string{"HSET", cmd.Key, cmd.Field, str})
+		if expireAt := h.ExpireAt(cmd.Field); !expireAt.IsZero() {
+			inst.Propagate([]string{"HPEXPIREAT", cmd.Key, strconv.FormatInt(expireAt.UnixMilli(), 10), "FIELDS", "1", cmd.Field})
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.Bulk(str)
+	return nil
+}
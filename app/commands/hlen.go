@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       HLEN,
+		Arity:      2,
+		Flags:      []string{FlagReadonly, FlagFast},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &HlenCommand{args[0]}, nil },
+		Summary:    "Returns the number of fields in a hash.",
+		Since:      "2.0.0",
+		Group:      GroupHash,
+		Complexity: "O(1)",
+	})
+}
+
+type HlenCommand struct {
+	Key string
+}
+
+func (cmd *HlenCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	n := 0
+
+	err := viewHash(inst, cmd.Key, func(h *instance.Hash) {
+		if h != nil {
+			n = h.Len()
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.Integer(int64(n))
+	return nil
+}
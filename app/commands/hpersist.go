@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"strconv"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:     HPERSIST,
+		Arity:    -5,
+		Flags:    []string{FlagWrite, FlagFast},
+		FirstKey: 1,
+		LastKey:  1,
+		Step:     1,
+		Create: func(args []string) (Command, error) {
+			fields, err := parseFields(args[1:])
+			if err != nil {
+				return nil, err
+			}
+			return &HpersistCommand{args[0], fields}, nil
+		},
+		Summary:    "Removes the expiration time for each specified field",
+		Since:      "7.4.0",
+		Group:      GroupHash,
+		Complexity: "O(N) where N is the number of specified fields",
+	})
+}
+
+// HpersistCommand implements HPERSIST. It replies with a code for each
+// field: -2 if it does not exist, -1 if it has no expiry and 1 if the expiry
+// was removed.
+type HpersistCommand struct {
+	Key    string
+	Fields []string
+}
+
+func (cmd *HpersistCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	codes := make([]int64, len(cmd.Fields))
+
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		h, err := getHash(tx, cmd.Key)
+		if err != nil {
+			return err
+		}
+
+		var persisted []string
+		for i, field := range cmd.Fields {
+			if h == nil {
+				codes[i] = -2
+				continue
+			}
+
+			if _, exists := h.Get(field); !exists {
+				codes[i] = -2
+				continue
+			}
+
+			if h.Persist(field) {
+				codes[i] = 1
+				persisted = append(persisted, field)
+			} else {
+				codes[i] = -1
+			}
+		}
+
+		if len(persisted) > 0 {
+			args := []string{"HPERSIST", cmd.Key, "FIELDS", strconv.Itoa(len(persisted))}
+			inst.Propagate(append(args, persisted...))
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.ArrayHeader(len(codes))
+	for _, code := range codes {
+		w.Integer(code)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package commands
+
+import (
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:     HRANDFIELD,
+		Arity:    -2,
+		Flags:    []string{FlagReadonly},
+		FirstKey: 1,
+		LastKey:  1,
+		Step:     1,
+		Create: func(args []string) (Command, error) {
+			cmd := &HrandfieldCommand{Key: args[0]}
+			if len(args) == 1 {
+				return cmd, nil
+			}
+
+			if len(args) > 3 || (len(args) == 3 && !strings.EqualFold(args[2], "WITHVALUES")) {
+				return nil, ErrSyntax
+			}
+
+			count, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return nil, ErrNotInteger
+			}
+			if count < -math.MaxInt64/2 || count > math.MaxInt64/2 {
+				return nil, errorf("value is out of range")
+			}
+
+			cmd.HasCount = true
+			cmd.Count = count
+			cmd.WithValues = len(args) == 3
+			return cmd, nil
+		},
+		Summary:    "Returns one or more random fields from a hash.",
+		Since:      "6.2.0",
+		Group:      GroupHash,
+		Complexity: "O(N) where N is the number of fields returned",
+	})
+}
+
+// HrandfieldCommand implements HRANDFIELD. A positive count returns distinct
+// fields, a negative count allows the same field to be returned repeatedly.
+type HrandfieldCommand struct {
+	Key        string
+	HasCount   bool
+	Count      int64
+	WithValues bool
+}
+
+func (cmd *HrandfieldCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var pairs [][2]string
+
+	err := viewHash(inst, cmd.Key, func(h *instance.Hash) {
+		if h == nil || cmd.Count == 0 && cmd.HasCount {
+			return
+		}
+
+		pairs = make([][2]string, 0, h.Len())
+		h.Range(func(field string, value string) bool {
+			pairs = append(pairs, [2]string{field, value})
+			return true
+		})
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if !cmd.HasCount {
+		if len(pairs) == 0 {
+			w.NullBulk()
+		} else {
+			w.Bulk(pairs[rand.IntN(len(pairs))][0])
+		}
+		return nil
+	}
+
+	// RESP3 clients get an array of pairs, RESP2 clients a flat array
+	header := func(n int) {
+		if cmd.WithValues && w.Proto != encode.RESP3 {
+			n *= 2
+		}
+		w.ArrayHeader(n)
+	}
+
+	write := func(pair [2]string) {
+		switch {
+		case !cmd.WithValues:
+			w.Bulk(pair[0])
+		case w.Proto == encode.RESP3:
+			w.Array(pair[:])
+		default:
+			w.Bulk(pair[0])
+			w.Bulk(pair[1])
+		}
+	}
+
+	// Fields are picked while writing, as the count is up to the client
+	if cmd.Count < 0 {
+		n := -cmd.Count
+		if len(pairs) == 0 {
+			n = 0
+		}
+
+		header(int(n))
+		for range n {
+			write(pairs[rand.IntN(len(pairs))])
+			if err := w.FlushLarge(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	rand.Shuffle(len(pairs), func(i, j int) { pairs[i], pairs[j] = pairs[j], pairs[i] })
+	picked := pairs[:min(int64(len(pairs)), cmd.Count)]
+
+	header(len(picked))
+	for _, pair := range picked {
+		write(pair)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+	"github.com/codecrafters-io/redis-starter-go/app/parser"
+)
+
+func TestHrandfieldCount(t *testing.T) {
+	inst := instance.NewInstance()
+	execute(t, inst, encode.RESP2, "HSET", "hash", "a", "1", "b", "2", "c", "3")
+	values := map[string]string{"a": "1", "b": "2", "c": "3"}
+
+	tests := []struct {
+		name   string
+		args   []string
+		proto  int
+		len    int
+		unique bool
+	}{
+		{"positive", []string{"2"}, encode.RESP2, 2, true},
+		{"positive above size", []string{"10"}, encode.RESP2, 3, true},
+		{"negative", []string{"-2"}, encode.RESP2, 2, false},
+		{"negative above size", []string{"-10"}, encode.RESP2, 10, false},
+		{"zero", []string{"0"}, encode.RESP2, 0, false},
+		{"negative with values", []string{"-10", "WITHVALUES"}, encode.RESP2, 10, false},
+		{"negative with values RESP3", []string{"-10", "WITHVALUES"}, encode.RESP3, 10, false},
+		{"positive with values RESP3", []string{"10", "WITHVALUES"}, encode.RESP3, 3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reply := execute(t, inst, tt.proto, append([]string{"HRANDFIELD", "hash"}, tt.args...)...)
+			withValues := len(tt.args) == 2
+
+			// Get the fields and values, which RESP2 clients receive as a
+			// flat array
+			var pairs [][2]string
+			switch {
+			case !withValues:
+				for _, v := range reply.Array {
+					pairs = append(pairs, [2]string{v.Str, values[v.Str]})
+				}
+			case tt.proto == encode.RESP3:
+				for _, v := range reply.Array {
+					if len(v.Array) != 2 {
+						t.Fatalf("reply holds %v, want a field value pair", v)
+					}
+					pairs = append(pairs, [2]string{v.Array[0].Str, v.Array[1].Str})
+				}
+			default:
+				if len(reply.Array)%2 != 0 {
+					t.Fatalf("reply has odd length %d", len(reply.Array))
+				}
+				for i := 0; i < len(reply.Array); i += 2 {
+					pairs = append(pairs, [2]string{reply.Array[i].Str, reply.Array[i+1].Str})
+				}
+			}
+
+			if reply.Type != parser.Array || len(pairs) != tt.len {
+				t.Fatalf("reply = %v, want an array of %d fields", reply, tt.len)
+			}
+
+			seen := make(map[string]bool)
+			for _, pair := range pairs {
+				if value, ok := values[pair[0]]; !ok || value != pair[1] {
+					t.Errorf("reply holds %q, which is not in the hash", pair)
+				}
+				if tt.unique && seen[pair[0]] {
+					t.Errorf("reply holds %q more than once", pair[0])
+				}
+				seen[pair[0]] = true
+			}
+		})
+	}
+}
+
+func TestHrandfieldMissingKey(t *testing.T) {
+	inst := instance.NewInstance()
+
+	if reply := execute(t, inst, encode.RESP2, "HRANDFIELD", "missing"); !reply.IsNull() {
+		t.Errorf("HRANDFIELD missing = %v, want null", reply)
+	}
+
+	for _, count := range []string{"5", "-5"} {
+		reply := execute(t, inst, encode.RESP2, "HRANDFIELD", "missing", count)
+		if reply.Type != parser.Array || len(reply.Array) != 0 {
+			t.Errorf("HRANDFIELD missing %s = %v, want an empty array", count, reply)
+		}
+	}
+}
+
+// TestHrandfieldHugeCount checks that a count far larger than any reply the
+// client could take is written while picking fields, and stops once the
+// connection fails.
+func TestHrandfieldHugeCount(t *testing.T) {
+	inst := instance.NewInstance()
+	execute(t, inst, encode.RESP2, "HSET", "hash", "a", "1")
+
+	cmd, err := CreateCommand("HRANDFIELD", []string{"hash", "-1000000000000", "WITHVALUES"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cmd.Execute(inst, encode.NewWriter(failingWriter{})); err != errClosed {
+		t.Errorf("Execute() error = %v, want %v", err, errClosed)
+	}
+}
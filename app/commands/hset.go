@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	hset := func(name string, summary string, since string) *Spec {
+		return &Spec{
+			Name:     name,
+			Arity:    -4,
+			Flags:    []string{FlagWrite, FlagDenyOOM, FlagFast},
+			FirstKey: 1,
+			LastKey:  1,
+			Step:     1,
+			Create: func(args []string) (Command, error) {
+				if len(args)%2 != 1 {
+					return nil, errorf("wrong number of arguments for '%s' command", name)
+				}
+				return &HsetCommand{Key: args[0], Pairs: args[1:], Multi: name == HMSET}, nil
+			},
+			Summary:    summary,
+			Since:      since,
+			Group:      GroupHash,
+			Complexity: "O(1) for each field/value pair added, so O(N) to add N field/value pairs when the command is called with multiple field/value pairs.",
+		}
+	}
+
+	register(hset(HSET, "Creates or modifies the value of a field in a hash.", "2.0.0"))
+	register(hset(HMSET, "Sets the values of multiple fields.", "2.0.0"))
+}
+
+// HsetCommand implements HSET and, with Multi set, the deprecated HMSET,
+// which only differs in its reply. Pairs holds fields and values
+// alternately.
+type HsetCommand struct {
+	Key   string
+	Pairs []string
+	Multi bool
+}
+
+func (cmd *HsetCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	added := 0
+
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		h, err := getOrCreateHash(tx, cmd.Key)
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < len(cmd.Pairs); i += 2 {
+			if h.Set(cmd.Pairs[i], cmd.Pairs[i+1]) {
+				added++
+			}
+		}
+
+		inst.Propagate(append([]string{"HSET", cmd.Key}, cmd.Pairs...))
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if cmd.Multi {
+		w.SimpleString("OK")
+	} else {
+		w.Integer(int64(added))
+	}
+	return nil
+}
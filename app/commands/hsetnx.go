@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       HSETNX,
+		Arity:      4,
+		Flags:      []string{FlagWrite, FlagDenyOOM, FlagFast},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &HsetnxCommand{args[0], args[1], args[2]}, nil },
+		Summary:    "Sets the value of a field in a hash only when the field doesn't exist.",
+		Since:      "2.0.0",
+		Group:      GroupHash,
+		Complexity: "O(1)",
+	})
+}
+
+type HsetnxCommand struct {
+	Key   string
+	Field string
+	Value string
+}
+
+func (cmd *HsetnxCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	added := false
+
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		h, err := getHash(tx, cmd.Key)
+		if err != nil {
+			return err
+		}
+
+		if h != nil {
+			if _, exists := h.Get(cmd.Field); exists {
+				return nil
+			}
+		}
+
+		h, _ = getOrCreateHash(tx, cmd.Key)
+		added = h.Set(cmd.Field, cmd.Value)
+		inst.Propagate([]string{"HSET", cmd.Key, cmd.Field, cmd.Value})
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if !added {
+		w.Integer(0)
+		return nil
+	}
+
+	w.Integer(1)
+	return nil
+}
@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       HSTRLEN,
+		Arity:      3,
+		Flags:      []string{FlagReadonly, FlagFast},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &HstrlenCommand{args[0], args[1]}, nil },
+		Summary:    "Returns the length of the value of a field.",
+		Since:      "3.2.0",
+		Group:      GroupHash,
+		Complexity: "O(1)",
+	})
+}
+
+type HstrlenCommand struct {
+	Key   string
+	Field string
+}
+
+func (cmd *HstrlenCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var value string
+
+	err := viewHash(inst, cmd.Key, func(h *instance.Hash) {
+		if h != nil {
+			value, _ = h.Get(cmd.Field)
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.Integer(int64(len(value)))
+	return nil
+}
@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	httl := func(name string, summary string) *Spec {
+		return &Spec{
+			Name:     name,
+			Arity:    -5,
+			Flags:    []string{FlagReadonly, FlagFast},
+			FirstKey: 1,
+			LastKey:  1,
+			Step:     1,
+			Create: func(args []string) (Command, error) {
+				fields, err := parseFields(args[1:])
+				if err != nil {
+					return nil, err
+				}
+
+				cmd := &HttlCommand{Key: args[0], Fields: fields}
+				cmd.Millis = name == HPTTL || name == HPEXPIRETIME
+				cmd.Absolute = name == HEXPIRETIME || name == HPEXPIRETIME
+				return cmd, nil
+			},
+			Summary:    summary,
+			Since:      "7.4.0",
+			Group:      GroupHash,
+			Complexity: "O(N) where N is the number of specified fields",
+		}
+	}
+
+	register(httl(HTTL, "Returns the TTL in seconds of a hash field."))
+	register(httl(HPTTL, "Returns the TTL in milliseconds of a hash field."))
+	register(httl(HEXPIRETIME, "Returns the expiration time of a hash field as a Unix timestamp, in seconds."))
+	register(httl(HPEXPIRETIME, "Returns the expiration time of a hash field as a Unix timestamp, in msec."))
+}
+
+// HttlCommand implements HTTL, HPTTL, HEXPIRETIME and HPEXPIRETIME. They
+// reply like their counterparts for keys, with one entry per field.
+type HttlCommand struct {
+	Key      string
+	Fields   []string
+	Millis   bool
+	Absolute bool
+}
+
+func (cmd *HttlCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	codes := make([]int64, len(cmd.Fields))
+
+	err := viewHash(inst, cmd.Key, func(h *instance.Hash) {
+		for i, field := range cmd.Fields {
+			if h == nil {
+				codes[i] = -2
+				continue
+			}
+
+			if _, exists := h.Get(field); !exists {
+				codes[i] = -2
+				continue
+			}
+
+			codes[i] = cmd.ttl(h.ExpireAt(field))
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.ArrayHeader(len(codes))
+	for _, code := range codes {
+		w.Integer(code)
+	}
+	return nil
+}
+
+// ttl converts the expiry of a field to the reply, -1 if it has none.
+func (cmd *HttlCommand) ttl(expireAt time.Time) int64 {
+	if expireAt.IsZero() {
+		return -1
+	}
+
+	if cmd.Absolute {
+		if cmd.Millis {
+			return expireAt.UnixMilli()
+		}
+		return expireAt.Unix()
+	}
+
+	ttl := max(time.Until(expireAt).Milliseconds(), 0)
+	if cmd.Millis {
+		return ttl
+	}
+	return (ttl + 500) / 1000
+}
@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	incr := func(name string, arity int, summary string) *Spec {
+		return &Spec{
+			Name:       name,
+			Arity:      arity,
+			Flags:      []string{FlagWrite, FlagDenyOOM, FlagFast},
+			FirstKey:   1,
+			LastKey:    1,
+			Step:       1,
+			Create:     func(args []string) (Command, error) { return NewIncrCommand(name, args) },
+			Summary:    summary,
+			Since:      "1.0.0",
+			Group:      GroupString,
+			Complexity: "O(1)",
+		}
+	}
+
+	register(incr(INCR, 2, "Increments the integer value of a key by one. Uses 0 as initial value if the key doesn't exist."))
+	register(incr(DECR, 2, "Decrements the integer value of a key by one. Uses 0 as initial value if the key doesn't exist."))
+	register(incr(INCRBY, 3, "Increments the integer value of a key by a number. Uses 0 as initial value if the key doesn't exist."))
+	register(incr(DECRBY, 3, "Decrements a number from the integer value of a key. Uses 0 as initial value if the key doesn't exist."))
+	register(&Spec{
+		Name:     INCRBYFLOAT,
+		Arity:    3,
+		Flags:    []string{FlagWrite, FlagDenyOOM, FlagFast},
+		FirstKey: 1,
+		LastKey:  1,
+		Step:     1,
+		Create: func(args []string) (Command, error) {
+			delta, err := strconv.ParseFloat(args[1], 64)
+			if err != nil || math.IsNaN(delta) {
+				return nil, ErrNotFloat
+			}
+			return &IncrByFloatCommand{args[0], delta}, nil
+		},
+		Summary:    "Increment the floating point value of a key by a number. Uses 0 as initial value if the key doesn't exist.",
+		Since:      "2.6.0",
+		Group:      GroupString,
+		Complexity: "O(1)",
+	})
+}
+
+// IncrCommand implements INCR, DECR, INCRBY and DECRBY, which all add Delta
+// to the integer stored at Key.
+type IncrCommand struct {
+	Key   string
+	Delta int64
+}
+
+func NewIncrCommand(name string, args []string) (*IncrCommand, error) {
+	cmd := &IncrCommand{Key: args[0], Delta: 1}
+
+	if name == INCRBY || name == DECRBY {
+		delta, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return nil, ErrNotInteger
+		}
+		cmd.Delta = delta
+	}
+
+	if name == DECR || name == DECRBY {
+		if cmd.Delta == math.MinInt64 {
+			return nil, errorf("decrement would overflow")
+		}
+		cmd.Delta = -cmd.Delta
+	}
+
+	return cmd, nil
+}
+
+func (cmd *IncrCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var n int64
+
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		v, ok, err := getString(tx, cmd.Key)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			if n, err = strconv.ParseInt(v.Value, 10, 64); err != nil {
+				return ErrNotInteger
+			}
+		}
+
+		if (cmd.Delta > 0 && n > math.MaxInt64-cmd.Delta) || (cmd.Delta < 0 && n < math.MinInt64-cmd.Delta) {
+			return errorf("increment or decrement would overflow")
+		}
+
+		n += cmd.Delta
+		v.Value = strconv.FormatInt(n, 10)
+		tx.Put(cmd.Key, v)
+		inst.Propagate([]string{"INCRBY", cmd.Key, strconv.FormatInt(cmd.Delta, 10)})
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.Integer(n)
+	return nil
+}
+
+// IncrByFloatCommand implements INCRBYFLOAT. The result is propagated as SET,
+// so replicas do not depend on the float arithmetic of the master.
+type IncrByFloatCommand struct {
+	Key   string
+	Delta float64
+}
+
+func (cmd *IncrByFloatCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var str string
+
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		v, ok, err := getString(tx, cmd.Key)
+		if err != nil {
+			return err
+		}
+
+		f := 0.0
+		if ok {
+			if f, err = strconv.ParseFloat(v.Value, 64); err != nil || math.IsNaN(f) {
+				return ErrNotFloat
+			}
+		}
+
+		f += cmd.Delta
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return errorf("increment would produce NaN or Infinity")
+		}
+
+		str = strconv.FormatFloat(f, 'f', -1, 64)
+		v.Value = str
+		tx.Put(cmd.Key, v)
+		inst.Propagate([]string{"SET", cmd.Key, str, "KEEPTTL"})
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.Bulk(str)
+	return nil
+}
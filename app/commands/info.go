@@ -2,20 +2,47 @@ package commands
 
 import (
 	"fmt"
-	"github.com/codecrafters-io/redis-starter-go/app/client"
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
 	"github.com/codecrafters-io/redis-starter-go/app/instance"
 )
 
+func init() {
+	register(&Spec{
+		Name:  INFO,
+		Arity: -1,
+		Flags: []string{FlagLoading, FlagStale},
+		Create: func(args []string) (Command, error) {
+			if len(args) == 0 {
+				return &InfoCommand{"default"}, nil
+			}
+			return &InfoCommand{strings.ToLower(args[0])}, nil
+		},
+		Summary:    "Returns information and statistics about the server.",
+		Since:      "1.0.0",
+		Group:      GroupServer,
+		Complexity: "O(1)",
+	})
+}
+
 type InfoCommand struct {
 	Section string
 }
 
-func (cmd *InfoCommand) Execute(inst *instance.Instance) ([]byte, error) {
-	if cmd.Section == "replication" {
-		repl := inst.Info["replication"]
-		str := fmt.Sprintf("# Replication\r\nrole:%s\r\nmaster_replid:%s\r\nmaster_repl_offset:%s\r\n", repl["role"], repl["master_replid"], repl["master_repl_offset"])
-		return client.EncodeBulk(str), nil
+func (cmd *InfoCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	all := cmd.Section == "default" || cmd.Section == "all"
+	var sections []string
+
+	if cmd.Section == "stats" || all {
+		sections = append(sections, fmt.Sprintf("# Stats\r\nexpired_keys:%d\r\nexpired_subkeys:%d\r\n", inst.Store.ExpiredKeys(), inst.Store.ExpiredFields()))
+	}
+
+	if cmd.Section == "replication" || all {
+		sections = append(sections, fmt.Sprintf("# Replication\r\nrole:%s\r\nmaster_replid:%s\r\nmaster_repl_offset:%d\r\n", inst.Role(), inst.GetInfo("replication", "master_replid"), inst.ReplOffset()))
 	}
 
-	return nil, fmt.Errorf("Info Command: Unknown Section %s", cmd.Section)
+	// Unknown sections are empty
+	w.Verbatim("txt", strings.Join(sections, "\r\n"))
+	return nil
 }
@@ -0,0 +1,236 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:  COMMAND,
+		Arity: -1,
+		Flags: []string{FlagLoading, FlagStale},
+		Create: func(args []string) (Command, error) {
+			if len(args) == 0 {
+				return &CommandCommand{SubCmd: "info", All: true}, nil
+			}
+
+			cmd := &CommandCommand{SubCmd: strings.ToLower(args[0]), Args: args[1:]}
+
+			switch cmd.SubCmd {
+			case "count", "list":
+				if len(cmd.Args) != 0 {
+					return nil, errorf("wrong number of arguments for 'command|%s' command", cmd.SubCmd)
+				}
+			case "info", "docs":
+				cmd.All = len(cmd.Args) == 0
+			case "getkeys":
+				if len(cmd.Args) == 0 {
+					return nil, errorf("wrong number of arguments for 'command|getkeys' command")
+				}
+			default:
+				return nil, errorf("unknown subcommand '%s'. Try COMMAND HELP.", args[0])
+			}
+
+			return cmd, nil
+		},
+		Summary:    "Returns detailed information about all commands.",
+		Since:      "2.8.13",
+		Group:      GroupServer,
+		Complexity: "O(N) where N is the total number of Redis commands",
+	})
+}
+
+// CommandCommand implements COMMAND and its subcommands COUNT, LIST, INFO,
+// DOCS and GETKEYS. All is set if INFO or DOCS should describe every command.
+type CommandCommand struct {
+	SubCmd string
+	Args   []string
+	All    bool
+}
+
+func (cmd *CommandCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	switch cmd.SubCmd {
+	case "count":
+		w.Integer(int64(len(registry)))
+	case "list":
+		specs := Specs()
+		w.ArrayHeader(len(specs))
+		for _, spec := range specs {
+			w.Bulk(spec.Name)
+		}
+	case "info":
+		if cmd.All {
+			specs := Specs()
+			w.ArrayHeader(len(specs))
+			for _, spec := range specs {
+				writeInfo(w, spec)
+			}
+			return nil
+		}
+
+		w.ArrayHeader(len(cmd.Args))
+		for _, name := range cmd.Args {
+			if spec, ok := Lookup(name); ok {
+				writeInfo(w, spec)
+			} else {
+				w.NullArray()
+			}
+		}
+	case "docs":
+		var specs []*Spec
+		if cmd.All {
+			specs = Specs()
+		} else {
+			// Unknown commands are left out
+			for _, name := range cmd.Args {
+				if spec, ok := Lookup(name); ok {
+					specs = append(specs, spec)
+				}
+			}
+		}
+
+		w.MapHeader(len(specs))
+		for _, spec := range specs {
+			w.Bulk(spec.Name)
+			writeDocs(w, spec)
+		}
+	case "getkeys":
+		spec, ok := Lookup(cmd.Args[0])
+		if !ok {
+			return errorf("Invalid command specified")
+		}
+
+		if !spec.CheckArity(len(cmd.Args)) {
+			return errorf("Invalid number of arguments specified for command")
+		}
+
+		keys := spec.Keys(cmd.Args[1:])
+		if len(keys) == 0 {
+			return errorf("The command has no key arguments")
+		}
+
+		w.Array(keys)
+	}
+
+	return nil
+}
+
+// writeInfo writes the reply of COMMAND INFO for a single command.
+func writeInfo(w *encode.Writer, spec *Spec) {
+	w.ArrayHeader(10)
+	w.Bulk(spec.Name)
+	w.Integer(int64(spec.Arity))
+
+	w.SetHeader(len(spec.Flags))
+	for _, flag := range spec.Flags {
+		w.SimpleString(flag)
+	}
+
+	w.Integer(int64(spec.FirstKey))
+	w.Integer(int64(spec.LastKey))
+	w.Integer(int64(spec.Step))
+
+	cats := spec.Categories()
+	w.SetHeader(len(cats))
+	for _, cat := range cats {
+		w.SimpleString(cat)
+	}
+
+	// Tips
+	w.ArrayHeader(0)
+
+	// Key specs
+	if spec.FirstKey == 0 && spec.GetKeys == nil {
+		w.ArrayHeader(0)
+	} else {
+		w.ArrayHeader(1)
+		writeKeySpec(w, spec)
+	}
+
+	// Subcommands
+	w.ArrayHeader(0)
+}
+
+// writeKeySpec describes where the keys of a command are found. Commands
+// with movable keys have to be asked using COMMAND GETKEYS.
+func writeKeySpec(w *encode.Writer, spec *Spec) {
+	var flags []string
+	if spec.HasFlag(FlagWrite) {
+		flags = []string{"RW", "UPDATE"}
+	} else {
+		flags = []string{"RO", "ACCESS"}
+	}
+
+	w.MapHeader(3)
+	w.Bulk("flags")
+	w.SetHeader(len(flags))
+	for _, flag := range flags {
+		w.SimpleString(flag)
+	}
+
+	if spec.GetKeys != nil {
+		w.Bulk("begin_search")
+		w.MapHeader(2)
+		w.Bulk("type")
+		w.Bulk("unknown")
+		w.Bulk("spec")
+		w.MapHeader(0)
+
+		w.Bulk("find_keys")
+		w.MapHeader(2)
+		w.Bulk("type")
+		w.Bulk("unknown")
+		w.Bulk("spec")
+		w.MapHeader(0)
+		return
+	}
+
+	// The last key is given relative to the first one
+	last := spec.LastKey
+	if last >= 0 {
+		last -= spec.FirstKey
+	}
+
+	w.Bulk("begin_search")
+	w.MapHeader(2)
+	w.Bulk("type")
+	w.Bulk("index")
+	w.Bulk("spec")
+	w.MapHeader(1)
+	w.Bulk("index")
+	w.Integer(int64(spec.FirstKey))
+
+	w.Bulk("find_keys")
+	w.MapHeader(2)
+	w.Bulk("type")
+	w.Bulk("range")
+	w.Bulk("spec")
+	w.MapHeader(3)
+	w.Bulk("lastkey")
+	w.Integer(int64(last))
+	w.Bulk("keystep")
+	w.Integer(int64(spec.Step))
+	w.Bulk("limit")
+	w.Integer(0)
+}
+
+// writeDocs writes the reply of COMMAND DOCS for a single command.
+func writeDocs(w *encode.Writer, spec *Spec) {
+	fields := [][2]string{
+		{"summary", spec.Summary},
+		{"since", spec.Since},
+		{"group", spec.Group},
+	}
+	if spec.Complexity != "" {
+		fields = append(fields, [2]string{"complexity", spec.Complexity})
+	}
+
+	w.MapHeader(len(fields))
+	for _, field := range fields {
+		w.Bulk(field[0])
+		w.Bulk(field[1])
+	}
+}
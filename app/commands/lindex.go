@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:     LINDEX,
+		Arity:    3,
+		Flags:    []string{FlagReadonly},
+		FirstKey: 1,
+		LastKey:  1,
+		Step:     1,
+		Create: func(args []string) (Command, error) {
+			index, err := parseInt(args[1])
+			if err != nil {
+				return nil, err
+			}
+			return &LindexCommand{args[0], index}, nil
+		},
+		Summary:    "Returns an element from a list by its index.",
+		Since:      "1.0.0",
+		Group:      GroupList,
+		Complexity: "O(N) where N is the number of elements to traverse to get to the element at index. This makes asking for the first or the last element of the list O(1).",
+	})
+}
+
+type LindexCommand struct {
+	Key   string
+	Index int64
+}
+
+func (cmd *LindexCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var elem string
+	found := false
+
+	err := inst.Store.View([]string{cmd.Key}, func(tx *instance.Tx) error {
+		l, err := getList(tx, cmd.Key)
+		if l == nil {
+			return err
+		}
+
+		if i, ok := listIndex(cmd.Index, l.Len()); ok {
+			elem, found = l.Index(i), true
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if found {
+		w.Bulk(elem)
+	} else {
+		w.NullBulk()
+	}
+	return nil
+}
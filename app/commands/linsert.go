@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:     LINSERT,
+		Arity:    5,
+		Flags:    []string{FlagWrite, FlagDenyOOM},
+		FirstKey: 1,
+		LastKey:  1,
+		Step:     1,
+		Create: func(args []string) (Command, error) {
+			cmd := &LinsertCommand{Key: args[0], Pivot: args[2], Elem: args[3]}
+
+			switch strings.ToLower(args[1]) {
+			case "before":
+				cmd.Before = true
+			case "after":
+			default:
+				return nil, ErrSyntax
+			}
+
+			return cmd, nil
+		},
+		Summary:    "Inserts an element before or after another element in a list.",
+		Since:      "2.2.0",
+		Group:      GroupList,
+		Complexity: "O(N) where N is the number of elements to traverse before seeing the value pivot. This means that inserting somewhere on the left end on the list (head) can be considered O(1) and inserting somewhere on the right end (tail) is O(N).",
+	})
+}
+
+// LinsertCommand implements LINSERT. It replies -1 if Pivot is not found and
+// 0 if the list does not exist.
+type LinsertCommand struct {
+	Key    string
+	Before bool
+	Pivot  string
+	Elem   string
+}
+
+func (cmd *LinsertCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	n := 0
+
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		l, err := getList(tx, cmd.Key)
+		if l == nil {
+			return err
+		}
+
+		pos := -1
+		l.Range(0, l.Len()-1, func(i int, elem string) bool {
+			if elem == cmd.Pivot {
+				pos = i
+				return false
+			}
+			return true
+		})
+
+		if pos < 0 {
+			n = -1
+			return nil
+		}
+
+		if !cmd.Before {
+			pos++
+		}
+
+		l.Insert(pos, cmd.Elem)
+		n = l.Len()
+
+		where := "AFTER"
+		if cmd.Before {
+			where = "BEFORE"
+		}
+		inst.Propagate([]string{"LINSERT", cmd.Key, where, cmd.Pivot, cmd.Elem})
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.Integer(int64(n))
+	return nil
+}
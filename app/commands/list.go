@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+// getList returns the list stored at key, or nil if the key does not exist.
+// Keys holding another type are an error.
+func getList(tx *instance.Tx, key string) (*instance.List, error) {
+	v, ok := tx.Get(key)
+	if !ok {
+		return nil, nil
+	}
+
+	if v.Kind != instance.KindList {
+		return nil, ErrWrongType
+	}
+	return v.List, nil
+}
+
+// putList stores a new list under key.
+func putList(tx *instance.Tx, key string, l *instance.List) {
+	tx.Put(key, instance.Value{Kind: instance.KindList, List: l})
+}
+
+// popList removes an element from the front or the back of l. Lists are
+// never left empty, the key is deleted along with the last element.
+func popList(tx *instance.Tx, key string, l *instance.List, left bool) string {
+	var elem string
+	if left {
+		elem, _ = l.PopFront()
+	} else {
+		elem, _ = l.PopBack()
+	}
+
+	if l.Len() == 0 {
+		tx.Delete(key)
+	}
+	return elem
+}
+
+// listRange resolves the inclusive range start to end of a list with n
+// elements. Negative indexes count from the end. The range is empty if
+// start is greater than end.
+func listRange(start int64, end int64, n int) (int, int) {
+	if start < 0 {
+		start += int64(n)
+	}
+	if end < 0 {
+		end += int64(n)
+	}
+
+	start = max(start, 0)
+	if start > end || start >= int64(n) {
+		return 0, -1
+	}
+
+	return int(start), int(min(end, int64(n)-1))
+}
+
+// listIndex resolves an index into a list with n elements, negative indexes
+// count from the end. It reports whether the index is in range.
+func listIndex(index int64, n int) (int, bool) {
+	if index < 0 {
+		index += int64(n)
+	}
+	if index < 0 || index >= int64(n) {
+		return 0, false
+	}
+	return int(index), true
+}
+
+// parseDirection parses the LEFT or RIGHT argument of LMOVE and LMPOP.
+func parseDirection(arg string) (bool, error) {
+	switch strings.ToLower(arg) {
+	case "left":
+		return true, nil
+	case "right":
+		return false, nil
+	}
+	return false, ErrSyntax
+}
+
+func direction(left bool) string {
+	if left {
+		return "LEFT"
+	}
+	return "RIGHT"
+}
+
+// parseInt parses an integer argument.
+func parseInt(arg string) (int64, error) {
+	n, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return 0, ErrNotInteger
+	}
+	return n, nil
+}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       LLEN,
+		Arity:      2,
+		Flags:      []string{FlagReadonly, FlagFast},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &LlenCommand{args[0]}, nil },
+		Summary:    "Returns the length of a list.",
+		Since:      "1.0.0",
+		Group:      GroupList,
+		Complexity: "O(1)",
+	})
+}
+
+type LlenCommand struct {
+	Key string
+}
+
+func (cmd *LlenCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var n int
+
+	err := inst.Store.View([]string{cmd.Key}, func(tx *instance.Tx) error {
+		l, err := getList(tx, cmd.Key)
+		if l != nil {
+			n = l.Len()
+		}
+		return err
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.Integer(int64(n))
+	return nil
+}
@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:     LMOVE,
+		Arity:    5,
+		Flags:    []string{FlagWrite, FlagDenyOOM},
+		FirstKey: 1,
+		LastKey:  2,
+		Step:     1,
+		Create: func(args []string) (Command, error) {
+			from, err := parseDirection(args[2])
+			if err != nil {
+				return nil, err
+			}
+
+			to, err := parseDirection(args[3])
+			if err != nil {
+				return nil, err
+			}
+
+			return &LmoveCommand{args[0], args[1], from, to}, nil
+		},
+		Summary:    "Returns an element after popping it from one list and pushing it to another. Deletes the list if the last element was moved.",
+		Since:      "6.2.0",
+		Group:      GroupList,
+		Complexity: "O(1)",
+	})
+	register(&Spec{
+		Name:       RPOPLPUSH,
+		Arity:      3,
+		Flags:      []string{FlagWrite, FlagDenyOOM},
+		FirstKey:   1,
+		LastKey:    2,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &LmoveCommand{args[0], args[1], false, true}, nil },
+		Summary:    "Returns the last element of a list after removing and pushing it to another list. Deletes the list if the last element was popped.",
+		Since:      "1.2.0",
+		Group:      GroupList,
+		Complexity: "O(1)",
+	})
+}
+
+// LmoveCommand implements LMOVE and RPOPLPUSH. FromLeft and ToLeft select
+// the ends of the source and destination lists. Src and Dst may be the same
+// list, which rotates it.
+type LmoveCommand struct {
+	Src      string
+	Dst      string
+	FromLeft bool
+	ToLeft   bool
+}
+
+func (cmd *LmoveCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var elem string
+	moved := false
+
+	err := inst.Store.Update([]string{cmd.Src, cmd.Dst}, func(tx *instance.Tx) error {
+		var err error
+		elem, moved, err = moveElement(tx, cmd.Src, cmd.Dst, cmd.FromLeft, cmd.ToLeft)
+		if moved {
+			inst.Propagate([]string{"LMOVE", cmd.Src, cmd.Dst, direction(cmd.FromLeft), direction(cmd.ToLeft)})
+		}
+		return err
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if !moved {
+		w.NullBulk()
+		return nil
+	}
+
+	inst.SignalReady(cmd.Dst)
+	w.Bulk(elem)
+	return nil
+}
+
+// moveElement pops an element from src and pushes it to dst. Both keys are
+// checked to hold lists before anything is changed. It reports whether src
+// had an element to move.
+func moveElement(tx *instance.Tx, src string, dst string, fromLeft bool, toLeft bool) (string, bool, error) {
+	srcList, err := getList(tx, src)
+	if srcList == nil {
+		return "", false, err
+	}
+
+	dstList, err := getList(tx, dst)
+	if err != nil {
+		return "", false, err
+	}
+
+	elem := popList(tx, src, srcList, fromLeft)
+
+	// The source list may just have been deleted, if it is the destination
+	if dstList == nil || dstList.Len() == 0 {
+		dstList = instance.NewList()
+		putList(tx, dst, dstList)
+	}
+
+	if toLeft {
+		dstList.PushFront(elem)
+	} else {
+		dstList.PushBack(elem)
+	}
+	return elem, true, nil
+}
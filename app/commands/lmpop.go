@@ -0,0 +1,146 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       LMPOP,
+		Arity:      -4,
+		Flags:      []string{FlagWrite},
+		GetKeys:    numKeys,
+		Create:     func(args []string) (Command, error) { return NewLmpopCommand(args) },
+		Summary:    "Returns multiple elements from a list after removing them. Deletes the list if the last element was popped.",
+		Since:      "7.0.0",
+		Group:      GroupList,
+		Complexity: "O(N+M) where N is the number of provided keys and M is the number of elements returned.",
+	})
+}
+
+// LmpopCommand implements LMPOP numkeys key [key ...] LEFT | RIGHT
+// [COUNT count]. Elements are popped from the first non-empty list.
+type LmpopCommand struct {
+	Keys  []string
+	Left  bool
+	Count int
+}
+
+func NewLmpopCommand(args []string) (*LmpopCommand, error) {
+	keys, rest, err := parseNumKeys(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rest) == 0 {
+		return nil, ErrSyntax
+	}
+
+	cmd := &LmpopCommand{Keys: keys, Count: 1}
+
+	if cmd.Left, err = parseDirection(rest[0]); err != nil {
+		return nil, err
+	}
+
+	if len(rest) > 1 {
+		if len(rest) != 3 || strings.ToLower(rest[1]) != "count" {
+			return nil, ErrSyntax
+		}
+
+		count, err := parseInt(rest[2])
+		if err != nil || count <= 0 {
+			return nil, errorf("count should be greater than 0")
+		}
+		cmd.Count = int(min(count, 1<<31))
+	}
+
+	return cmd, nil
+}
+
+func (cmd *LmpopCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var key string
+	var elems []string
+
+	err := inst.Store.Update(cmd.Keys, func(tx *instance.Tx) error {
+		var err error
+		key, elems, err = popFirstList(tx, cmd.Keys, cmd.Left, cmd.Count)
+		if elems != nil {
+			propagatePop(inst, key, cmd.Left, len(elems))
+		}
+		return err
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if elems == nil {
+		w.NullArray()
+		return nil
+	}
+
+	w.ArrayHeader(2)
+	w.Bulk(key)
+	w.Array(elems)
+	return nil
+}
+
+// popFirstList pops up to count elements from the first of keys holding a
+// list. It returns no elements if all lists are empty.
+func popFirstList(tx *instance.Tx, keys []string, left bool, count int) (string, []string, error) {
+	for _, key := range keys {
+		l, err := getList(tx, key)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if l == nil {
+			continue
+		}
+
+		elems := make([]string, 0, min(count, l.Len()))
+		for len(elems) < count && l.Len() > 0 {
+			elems = append(elems, popList(tx, key, l, left))
+		}
+		return key, elems, nil
+	}
+
+	return "", nil, nil
+}
+
+// propagatePop propagates popping n elements from key.
+func propagatePop(inst *instance.Instance, key string, left bool, n int) {
+	name := "RPOP"
+	if left {
+		name = "LPOP"
+	}
+	inst.Propagate([]string{name, key, strconv.Itoa(n)})
+}
+
+// parseNumKeys splits the arguments of a command taking numkeys followed by
+// that many keys. It returns the keys and the remaining arguments.
+func parseNumKeys(args []string) ([]string, []string, error) {
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n <= 0 {
+		return nil, nil, errorf("numkeys should be greater than 0")
+	}
+
+	if n > len(args)-1 {
+		return nil, nil, ErrSyntax
+	}
+
+	return args[1 : n+1], args[n+1:], nil
+}
+
+// numKeys returns the keys of a command whose first argument is numkeys.
+func numKeys(args []string) []string {
+	keys, _, err := parseNumKeys(args)
+	if err != nil {
+		return nil
+	}
+	return keys
+}
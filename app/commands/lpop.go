@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"strconv"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	pop := func(name string, summary string) *Spec {
+		return &Spec{
+			Name:     name,
+			Arity:    -2,
+			Flags:    []string{FlagWrite, FlagFast},
+			FirstKey: 1,
+			LastKey:  1,
+			Step:     1,
+			Create: func(args []string) (Command, error) {
+				cmd := &PopCommand{Key: args[0], Left: name == LPOP, Count: -1}
+
+				if len(args) > 2 {
+					return nil, errorf("wrong number of arguments for '%s' command", name)
+				}
+
+				if len(args) == 2 {
+					count, err := parseInt(args[1])
+					if err != nil || count < 0 {
+						return nil, errorf("value is out of range, must be positive")
+					}
+					cmd.Count = int(min(count, 1<<31))
+				}
+
+				return cmd, nil
+			},
+			Summary:    summary,
+			Since:      "1.0.0",
+			Group:      GroupList,
+			Complexity: "O(N) where N is the number of elements returned",
+		}
+	}
+
+	register(pop(LPOP, "Returns the first elements in a list after removing it. Deletes the list if the last element was popped."))
+	register(pop(RPOP, "Returns and removes the last elements of the list. Deletes the list if the last element was popped."))
+}
+
+// PopCommand implements LPOP and RPOP. A Count of -1 means the count was
+// not given, in which case a single element is replied instead of an array.
+type PopCommand struct {
+	Key   string
+	Left  bool
+	Count int
+}
+
+func (cmd *PopCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var elems []string
+	var exists bool
+
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		l, err := getList(tx, cmd.Key)
+		if l == nil {
+			return err
+		}
+		exists = true
+
+		n := min(max(cmd.Count, 1), l.Len())
+		if cmd.Count == 0 {
+			n = 0
+		}
+
+		for range n {
+			elems = append(elems, popList(tx, cmd.Key, l, cmd.Left))
+		}
+
+		if len(elems) > 0 {
+			name := "RPOP"
+			if cmd.Left {
+				name = "LPOP"
+			}
+			inst.Propagate([]string{name, cmd.Key, strconv.Itoa(len(elems))})
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case cmd.Count < 0 && !exists:
+		w.NullBulk()
+	case cmd.Count < 0:
+		w.Bulk(elems[0])
+	case !exists:
+		w.NullArray()
+	default:
+		w.Array(elems)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"math"
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       LPOS,
+		Arity:      -3,
+		Flags:      []string{FlagReadonly},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return NewLposCommand(args) },
+		Summary:    "Returns the index of matching elements in a list.",
+		Since:      "6.0.6",
+		Group:      GroupList,
+		Complexity: "O(N) where N is the number of elements in the list, for the average case. When searching for elements near the head or the tail of the list, or when the MAXLEN option is provided, the command may run in constant time.",
+	})
+}
+
+// LposCommand implements LPOS key element [RANK rank] [COUNT num-matches]
+// [MAXLEN len]. A negative Rank searches from the back. Count is -1 if the
+// option was not given, 0 asks for all matches. A MaxLen of 0 compares all
+// elements.
+type LposCommand struct {
+	Key    string
+	Elem   string
+	Rank   int64
+	Count  int64
+	MaxLen int64
+}
+
+func NewLposCommand(args []string) (*LposCommand, error) {
+	cmd := &LposCommand{Key: args[0], Elem: args[1], Rank: 1, Count: -1}
+
+	for i := 2; i < len(args); i += 2 {
+		if i+1 >= len(args) {
+			return nil, ErrSyntax
+		}
+
+		n, err := parseInt(args[i+1])
+		if err != nil {
+			return nil, err
+		}
+
+		switch strings.ToLower(args[i]) {
+		case "rank":
+			if n == 0 {
+				return nil, errorf("RANK can't be zero: use 1 to start from the first match, 2 from the second ... or use negative to start from the last match")
+			}
+			if n == math.MinInt64 {
+				return nil, errorf("value is out of range, value must between -9223372036854775807 and 9223372036854775807")
+			}
+			cmd.Rank = n
+		case "count":
+			if n < 0 {
+				return nil, errorf("COUNT can't be negative")
+			}
+			cmd.Count = n
+		case "maxlen":
+			if n < 0 {
+				return nil, errorf("MAXLEN can't be negative")
+			}
+			cmd.MaxLen = n
+		default:
+			return nil, ErrSyntax
+		}
+	}
+
+	return cmd, nil
+}
+
+func (cmd *LposCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var matches []int
+
+	err := inst.Store.View([]string{cmd.Key}, func(tx *instance.Tx) error {
+		l, err := getList(tx, cmd.Key)
+		if l == nil {
+			return err
+		}
+
+		skip := max(cmd.Rank, -cmd.Rank) - 1
+		compared := int64(0)
+
+		match := func(i int, elem string) bool {
+			if cmd.MaxLen > 0 && compared == cmd.MaxLen {
+				return false
+			}
+			compared++
+
+			if elem != cmd.Elem {
+				return true
+			}
+
+			if skip > 0 {
+				skip--
+				return true
+			}
+
+			matches = append(matches, i)
+			return cmd.Count == 0 || int64(len(matches)) < max(cmd.Count, 1)
+		}
+
+		if cmd.Rank > 0 {
+			l.Range(0, l.Len()-1, match)
+		} else {
+			l.ReverseRange(0, l.Len()-1, match)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if cmd.Count < 0 {
+		if len(matches) == 0 {
+			w.NullBulk()
+		} else {
+			w.Integer(int64(matches[0]))
+		}
+		return nil
+	}
+
+	w.ArrayHeader(len(matches))
+	for _, i := range matches {
+		w.Integer(int64(i))
+	}
+	return nil
+}
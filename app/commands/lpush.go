@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	push := func(name string, summary string, since string) *Spec {
+		return &Spec{
+			Name:     name,
+			Arity:    -3,
+			Flags:    []string{FlagWrite, FlagDenyOOM, FlagFast},
+			FirstKey: 1,
+			LastKey:  1,
+			Step:     1,
+			Create: func(args []string) (Command, error) {
+				cmd := &PushCommand{Name: name, Key: args[0], Elems: args[1:]}
+				cmd.Left = name == LPUSH || name == LPUSHX
+				cmd.Exists = name == LPUSHX || name == RPUSHX
+				return cmd, nil
+			},
+			Summary:    summary,
+			Since:      since,
+			Group:      GroupList,
+			Complexity: "O(1) for each element added, so O(N) to add N elements when the command is called with multiple arguments.",
+		}
+	}
+
+	register(push(LPUSH, "Prepends one or more elements to a list. Creates the key if it doesn't exist.", "1.0.0"))
+	register(push(RPUSH, "Appends one or more elements to a list. Creates the key if it doesn't exist.", "1.0.0"))
+	register(push(LPUSHX, "Prepends one or more elements to a list only when the list exists.", "2.2.0"))
+	register(push(RPUSHX, "Appends one or more elements to a list only when the list exists.", "2.2.0"))
+}
+
+// PushCommand implements LPUSH, RPUSH, LPUSHX and RPUSHX. With Exists set,
+// elements are only pushed to an existing list.
+type PushCommand struct {
+	Name   string
+	Key    string
+	Elems  []string
+	Left   bool
+	Exists bool
+}
+
+func (cmd *PushCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var n int
+
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		l, err := getList(tx, cmd.Key)
+		if err != nil || (l == nil && cmd.Exists) {
+			return err
+		}
+
+		if l == nil {
+			l = instance.NewList()
+			putList(tx, cmd.Key, l)
+		}
+
+		for _, elem := range cmd.Elems {
+			if cmd.Left {
+				l.PushFront(elem)
+			} else {
+				l.PushBack(elem)
+			}
+		}
+
+		n = l.Len()
+		inst.Propagate(append([]string{strings.ToUpper(cmd.Name), cmd.Key}, cmd.Elems...))
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if n > 0 {
+		inst.SignalReady(cmd.Key)
+	}
+
+	w.Integer(int64(n))
+	return nil
+}
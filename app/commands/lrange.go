@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:     LRANGE,
+		Arity:    4,
+		Flags:    []string{FlagReadonly},
+		FirstKey: 1,
+		LastKey:  1,
+		Step:     1,
+		Create: func(args []string) (Command, error) {
+			start, err := parseInt(args[1])
+			if err != nil {
+				return nil, err
+			}
+
+			end, err := parseInt(args[2])
+			if err != nil {
+				return nil, err
+			}
+
+			return &LrangeCommand{args[0], start, end}, nil
+		},
+		Summary:    "Returns a range of elements from a list.",
+		Since:      "1.0.0",
+		Group:      GroupList,
+		Complexity: "O(S+N) where S is the distance of start offset from HEAD for small lists, from nearest end (HEAD or TAIL) for large lists; and N is the number of elements in the specified range.",
+	})
+}
+
+type LrangeCommand struct {
+	Key   string
+	Start int64
+	End   int64
+}
+
+func (cmd *LrangeCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var elems []string
+
+	err := inst.Store.View([]string{cmd.Key}, func(tx *instance.Tx) error {
+		l, err := getList(tx, cmd.Key)
+		if l == nil {
+			return err
+		}
+
+		start, end := listRange(cmd.Start, cmd.End, l.Len())
+		elems = make([]string, 0, end-start+1)
+		l.Range(start, end, func(_ int, elem string) bool {
+			elems = append(elems, elem)
+			return true
+		})
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.Array(elems)
+	return nil
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"strconv"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:     LREM,
+		Arity:    4,
+		Flags:    []string{FlagWrite},
+		FirstKey: 1,
+		LastKey:  1,
+		Step:     1,
+		Create: func(args []string) (Command, error) {
+			count, err := parseInt(args[1])
+			if err != nil {
+				return nil, err
+			}
+			return &LremCommand{args[0], count, args[2]}, nil
+		},
+		Summary:    "Removes elements from a list. Deletes the list if the last element was removed.",
+		Since:      "1.0.0",
+		Group:      GroupList,
+		Complexity: "O(N+M) where N is the length of the list and M is the number of elements removed.",
+	})
+}
+
+// LremCommand implements LREM. A positive Count removes occurrences from the
+// front, a negative one from the back and 0 removes all occurrences.
+type LremCommand struct {
+	Key   string
+	Count int64
+	Elem  string
+}
+
+func (cmd *LremCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var n int
+
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		l, err := getList(tx, cmd.Key)
+		if l == nil {
+			return err
+		}
+
+		count := int(max(min(cmd.Count, int64(l.Len())), -int64(l.Len())))
+		n = l.Remove(cmd.Elem, count)
+		if l.Len() == 0 {
+			tx.Delete(cmd.Key)
+		}
+
+		if n > 0 {
+			inst.Propagate([]string{"LREM", cmd.Key, strconv.FormatInt(cmd.Count, 10), cmd.Elem})
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.Integer(int64(n))
+	return nil
+}
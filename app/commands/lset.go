@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"strconv"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:     LSET,
+		Arity:    4,
+		Flags:    []string{FlagWrite, FlagDenyOOM},
+		FirstKey: 1,
+		LastKey:  1,
+		Step:     1,
+		Create: func(args []string) (Command, error) {
+			index, err := parseInt(args[1])
+			if err != nil {
+				return nil, err
+			}
+			return &LsetCommand{args[0], index, args[2]}, nil
+		},
+		Summary:    "Sets the value of an element in a list by its index.",
+		Since:      "1.0.0",
+		Group:      GroupList,
+		Complexity: "O(N) where N is the length of the list. Setting either the first or the last element of the list is O(1).",
+	})
+}
+
+type LsetCommand struct {
+	Key   string
+	Index int64
+	Elem  string
+}
+
+func (cmd *LsetCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		l, err := getList(tx, cmd.Key)
+		if err != nil {
+			return err
+		}
+
+		if l == nil {
+			return errorf("no such key")
+		}
+
+		i, ok := listIndex(cmd.Index, l.Len())
+		if !ok {
+			return errorf("index out of range")
+		}
+
+		l.Set(i, cmd.Elem)
+		inst.Propagate([]string{"LSET", cmd.Key, strconv.FormatInt(cmd.Index, 10), cmd.Elem})
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.SimpleString("OK")
+	return nil
+}
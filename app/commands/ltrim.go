@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"strconv"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:     LTRIM,
+		Arity:    4,
+		Flags:    []string{FlagWrite},
+		FirstKey: 1,
+		LastKey:  1,
+		Step:     1,
+		Create: func(args []string) (Command, error) {
+			start, err := parseInt(args[1])
+			if err != nil {
+				return nil, err
+			}
+
+			end, err := parseInt(args[2])
+			if err != nil {
+				return nil, err
+			}
+
+			return &LtrimCommand{args[0], start, end}, nil
+		},
+		Summary:    "Removes elements from both ends a list. Deletes the list if all elements were trimmed.",
+		Since:      "1.0.0",
+		Group:      GroupList,
+		Complexity: "O(N) where N is the number of elements to be removed by the operation.",
+	})
+}
+
+type LtrimCommand struct {
+	Key   string
+	Start int64
+	End   int64
+}
+
+func (cmd *LtrimCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		l, err := getList(tx, cmd.Key)
+		if l == nil {
+			return err
+		}
+
+		n := l.Len()
+		start, end := listRange(cmd.Start, cmd.End, n)
+		if start == 0 && end == n-1 {
+			return nil
+		}
+
+		l.Trim(start, end)
+		if l.Len() == 0 {
+			tx.Delete(cmd.Key)
+		}
+		inst.Propagate([]string{"LTRIM", cmd.Key, strconv.FormatInt(cmd.Start, 10), strconv.FormatInt(cmd.End, 10)})
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.SimpleString("OK")
+	return nil
+}
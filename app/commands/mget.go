@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       MGET,
+		Arity:      -2,
+		Flags:      []string{FlagReadonly, FlagFast},
+		FirstKey:   1,
+		LastKey:    -1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &MgetCommand{args}, nil },
+		Summary:    "Atomically returns the string values of one or more keys.",
+		Since:      "1.0.0",
+		Group:      GroupString,
+		Complexity: "O(N) where N is the number of keys to retrieve.",
+	})
+}
+
+type MgetCommand struct {
+	Keys []string
+}
+
+func (cmd *MgetCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	values := make([]instance.Value, len(cmd.Keys))
+	found := make([]bool, len(cmd.Keys))
+
+	inst.Store.View(cmd.Keys, func(tx *instance.Tx) error {
+		for i, key := range cmd.Keys {
+			// Other types are reported as missing
+			values[i], found[i] = tx.Get(key)
+			found[i] = found[i] && values[i].Kind == instance.KindString
+		}
+		return nil
+	})
+
+	w.ArrayHeader(len(cmd.Keys))
+	for i, v := range values {
+		if found[i] {
+			w.Bulk(v.Value)
+		} else {
+			w.NullBulk()
+		}
+	}
+	return nil
+}
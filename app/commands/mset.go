@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	mset := func(name string, summary string) *Spec {
+		return &Spec{
+			Name:     name,
+			Arity:    -3,
+			Flags:    []string{FlagWrite, FlagDenyOOM},
+			FirstKey: 1,
+			LastKey:  -1,
+			Step:     2,
+			Create: func(args []string) (Command, error) {
+				if len(args)%2 != 0 {
+					return nil, errorf("wrong number of arguments for '%s' command", name)
+				}
+				return &MsetCommand{Pairs: args, NX: name == MSETNX}, nil
+			},
+			Summary:    summary,
+			Since:      "1.0.1",
+			Group:      GroupString,
+			Complexity: "O(N) where N is the number of keys to set.",
+		}
+	}
+
+	register(mset(MSET, "Atomically creates or modifies the string values of one or more keys."))
+	register(mset(MSETNX, "Atomically modifies the string values of one or more keys only when all keys don't exist."))
+}
+
+// MsetCommand implements MSET and, with NX set, MSETNX. Pairs holds the keys
+// and values alternately. All keys are written at once, so no client sees
+// only some of them.
+type MsetCommand struct {
+	Pairs []string
+	NX    bool
+}
+
+func (cmd *MsetCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	keys := make([]string, 0, len(cmd.Pairs)/2)
+	for i := 0; i < len(cmd.Pairs); i += 2 {
+		keys = append(keys, cmd.Pairs[i])
+	}
+
+	written := true
+
+	inst.Store.Update(keys, func(tx *instance.Tx) error {
+		if cmd.NX {
+			for _, key := range keys {
+				if _, ok := tx.Get(key); ok {
+					written = false
+					return nil
+				}
+			}
+		}
+
+		for i := 0; i < len(cmd.Pairs); i += 2 {
+			tx.Put(cmd.Pairs[i], instance.Value{Value: cmd.Pairs[i+1]})
+		}
+
+		// Replicas write the keys unconditionally, the master already checked
+		inst.Propagate(append([]string{"MSET"}, cmd.Pairs...))
+		return nil
+	})
+
+	if !cmd.NX {
+		w.SimpleString("OK")
+	} else if written {
+		w.Integer(1)
+	} else {
+		w.Integer(0)
+	}
+	return nil
+}
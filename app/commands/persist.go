@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       PERSIST,
+		Arity:      2,
+		Flags:      []string{FlagWrite, FlagFast},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &PersistCommand{args[0]}, nil },
+		Summary:    "Removes the expiration time of a key.",
+		Since:      "2.2.0",
+		Group:      GroupGeneric,
+		Complexity: "O(1)",
+	})
+}
+
+type PersistCommand struct {
+	Key string
+}
+
+func (cmd *PersistCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	persisted := false
+
+	inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		if persisted = tx.Persist(cmd.Key); persisted {
+			inst.Propagate([]string{"PERSIST", cmd.Key})
+		}
+		return nil
+	})
+
+	if !persisted {
+		w.Integer(0)
+	} else {
+		w.Integer(1)
+	}
+	return nil
+}
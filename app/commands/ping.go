@@ -1,15 +1,38 @@
 package commands
 
 import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
 	"github.com/codecrafters-io/redis-starter-go/app/instance"
 )
 
-type PingCommand struct{}
+func init() {
+	register(&Spec{
+		Name:  PING,
+		Arity: -1,
+		Flags: []string{FlagFast},
+		Create: func(args []string) (Command, error) {
+			if len(args) > 1 {
+				return nil, errorf("wrong number of arguments for 'ping' command")
+			}
+			return &PingCommand{args}, nil
+		},
+		Summary:    "Returns the server's liveliness response.",
+		Since:      "1.0.0",
+		Group:      GroupConnection,
+		Complexity: "O(1)",
+	})
+}
+
+type PingCommand struct {
+	Message []string
+}
 
-func (cmd *PingCommand) Execute(inst *instance.Instance) ([]byte, error) {
-	if inst.Info["replication"]["role"] == "slave" {
-		inst.Offset += 14
+func (cmd *PingCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	if len(cmd.Message) > 0 {
+		w.Bulk(cmd.Message[0])
+		return nil
 	}
 
-	return []byte("+PONG\r\n"), nil
+	w.SimpleString("PONG")
+	return nil
 }
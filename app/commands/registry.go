@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Command flags, as reported by COMMAND INFO
+const (
+	FlagWrite    = "write"
+	FlagReadonly = "readonly"
+	FlagDenyOOM  = "denyoom"
+	FlagAdmin    = "admin"
+	FlagNoScript = "noscript"
+	FlagBlocking = "blocking"
+	FlagLoading  = "loading"
+	FlagStale    = "stale"
+	FlagFast     = "fast"
+	FlagNoAuth   = "no_auth"
+	FlagNoMulti  = "no_multi"
+
+	// Set automatically for commands whose keys are found by GetKeys
+	FlagMovableKeys = "movablekeys"
+)
+
+// Command groups, as reported by COMMAND DOCS
+const (
+	GroupConnection = "connection"
+	GroupGeneric    = "generic"
+	GroupServer     = "server"
+	GroupString     = "string"
+	GroupList       = "list"
+	GroupHash       = "hash"
+	GroupSet        = "set"
+	GroupSortedSet  = "sorted-set"
+)
+
+// Spec describes a command and how to create it from its arguments.
+//
+// Arity counts the command name itself, a negative arity -N means at least
+// N arguments. The keys of a command are found at positions FirstKey to
+// LastKey, Step apart, where a negative LastKey counts from the end. Commands
+// without keys have all three set to 0. Commands whose keys can not be
+// described by positions, e.g. because the number of keys is an argument,
+// provide GetKeys instead.
+type Spec struct {
+	Name     string
+	Arity    int
+	Flags    []string
+	FirstKey int
+	LastKey  int
+	Step     int
+	GetKeys  func(args []string) []string
+	Create   func(args []string) (Command, error)
+
+	// Documentation, as reported by COMMAND DOCS
+	Summary    string
+	Since      string
+	Group      string
+	Complexity string
+}
+
+var registry = make(map[string]*Spec)
+
+// register adds spec to the registry, it is called by the init functions of
+// the individual commands.
+func register(spec *Spec) {
+	if spec.GetKeys != nil {
+		spec.Flags = append(spec.Flags, FlagMovableKeys)
+	}
+	registry[spec.Name] = spec
+}
+
+// Lookup returns the spec of the command called name, ignoring case.
+func Lookup(name string) (*Spec, bool) {
+	spec, ok := registry[strings.ToLower(name)]
+	return spec, ok
+}
+
+// Specs returns all registered commands sorted by name.
+func Specs() []*Spec {
+	specs := make([]*Spec, 0, len(registry))
+	for _, spec := range registry {
+		specs = append(specs, spec)
+	}
+
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].Name < specs[j].Name
+	})
+	return specs
+}
+
+// CheckArity reports whether argc arguments, including the command name,
+// are acceptable for the command.
+func (spec *Spec) CheckArity(argc int) bool {
+	if spec.Arity < 0 {
+		return argc >= -spec.Arity
+	}
+	return argc == spec.Arity
+}
+
+func (spec *Spec) HasFlag(flag string) bool {
+	return slices.Contains(spec.Flags, flag)
+}
+
+// Keys returns the keys accessed by the command with the given arguments,
+// not including the command name.
+func (spec *Spec) Keys(args []string) []string {
+	if spec.GetKeys != nil {
+		return spec.GetKeys(args)
+	}
+
+	if spec.FirstKey == 0 {
+		return nil
+	}
+
+	last := spec.LastKey
+	if last < 0 {
+		last = len(args) + 1 + last
+	}
+
+	var keys []string
+	for i := spec.FirstKey; i <= last && i <= len(args); i += spec.Step {
+		keys = append(keys, args[i-1])
+	}
+	return keys
+}
+
+// Categories returns the ACL categories of the command, derived from its
+// flags and group.
+func (spec *Spec) Categories() []string {
+	var cats []string
+
+	if spec.HasFlag(FlagWrite) {
+		cats = append(cats, "@write")
+	}
+	if spec.HasFlag(FlagReadonly) {
+		cats = append(cats, "@read")
+	}
+
+	switch spec.Group {
+	case GroupGeneric:
+		cats = append(cats, "@keyspace")
+	case GroupString, GroupList, GroupHash, GroupSet:
+		cats = append(cats, "@"+spec.Group)
+	case GroupSortedSet:
+		cats = append(cats, "@sortedset")
+	case GroupConnection:
+		cats = append(cats, "@connection")
+	}
+
+	if spec.HasFlag(FlagAdmin) {
+		cats = append(cats, "@admin", "@dangerous")
+	}
+	if spec.HasFlag(FlagBlocking) {
+		cats = append(cats, "@blocking")
+	}
+
+	if spec.HasFlag(FlagFast) {
+		cats = append(cats, "@fast")
+	} else {
+		cats = append(cats, "@slow")
+	}
+
+	return cats
+}
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       RENAME,
+		Arity:      3,
+		Flags:      []string{FlagWrite},
+		FirstKey:   1,
+		LastKey:    2,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &RenameCommand{args[0], args[1], false}, nil },
+		Summary:    "Renames a key and overwrites the destination.",
+		Since:      "1.0.0",
+		Group:      GroupGeneric,
+		Complexity: "O(1)",
+	})
+	register(&Spec{
+		Name:       RENAMENX,
+		Arity:      3,
+		Flags:      []string{FlagWrite, FlagFast},
+		FirstKey:   1,
+		LastKey:    2,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &RenameCommand{args[0], args[1], true}, nil },
+		Summary:    "Renames a key only when the target key name doesn't exist.",
+		Since:      "1.0.0",
+		Group:      GroupGeneric,
+		Complexity: "O(1)",
+	})
+}
+
+// RenameCommand implements RENAME and, with NX set, RENAMENX. The value
+// keeps its time to live.
+type RenameCommand struct {
+	Src string
+	Dst string
+	NX  bool
+}
+
+func (cmd *RenameCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	if cmd.NX && cmd.Src == cmd.Dst {
+		if !inst.Store.Contains(cmd.Src) {
+			return errorf("no such key")
+		}
+		w.Integer(0)
+		return nil
+	}
+
+	var exists, moved bool
+
+	inst.Store.Update([]string{cmd.Src, cmd.Dst}, func(tx *instance.Tx) error {
+		exists, moved = tx.Rename(cmd.Src, cmd.Dst, cmd.NX)
+
+		if moved && cmd.NX {
+			inst.Propagate([]string{"RENAMENX", cmd.Src, cmd.Dst})
+		} else if moved {
+			inst.Propagate([]string{"RENAME", cmd.Src, cmd.Dst})
+		}
+		return nil
+	})
+
+	if !exists {
+		return errorf("no such key")
+	}
+
+	if moved {
+		inst.SignalReady(cmd.Dst)
+	}
+
+	if !cmd.NX {
+		w.SimpleString("OK")
+	} else if moved {
+		w.Integer(1)
+	} else {
+		w.Integer(0)
+	}
+
+	return nil
+}
@@ -9,21 +9,36 @@ import (
 	"github.com/codecrafters-io/redis-starter-go/app/instance"
 )
 
+func init() {
+	register(&Spec{
+		Name:  REPLCONF,
+		Arity: -3,
+		Flags: []string{FlagAdmin, FlagNoScript, FlagLoading, FlagStale},
+		Create: func(args []string) (Command, error) {
+			return &ReplconfCommand{strings.ToLower(args[0]), strings.ToLower(args[1])}, nil
+		},
+		Summary:    "An internal command for configuring the replication stream.",
+		Since:      "3.0.0",
+		Group:      GroupServer,
+		Complexity: "O(1)",
+	})
+}
+
 type ReplconfCommand struct {
 	SubCmd string
 	Arg    string
 }
 
-func (cmd *ReplconfCommand) Execute(inst *instance.Instance) ([]byte, error) {
+func (cmd *ReplconfCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
 	if strings.ToLower(cmd.SubCmd) == "getack" {
-		offset := inst.Offset
-		inst.Offset += 37
-		return encode.EncodeArray([]string{"REPLCONF", "ACK", strconv.Itoa(offset)}), nil
+		w.Array([]string{"REPLCONF", "ACK", strconv.FormatInt(inst.ReplOffset(), 10)})
+		return nil
 	} else if strings.ToLower(cmd.SubCmd) == "ack" {
 		inst.IncrementACK()
 		fmt.Println("Ack count: ", inst.GetAckCnt())
-		return nil, nil
+		return nil
 	}
 
-	return []byte("+OK\r\n"), nil
+	w.SimpleString("OK")
+	return nil
 }
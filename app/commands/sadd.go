@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       SADD,
+		Arity:      -3,
+		Flags:      []string{FlagWrite, FlagDenyOOM, FlagFast},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &SaddCommand{args[0], args[1:]}, nil },
+		Summary:    "Adds one or more members to a set. Creates the key if it doesn't exist.",
+		Since:      "1.0.0",
+		Group:      GroupSet,
+		Complexity: "O(1) for each element added, so O(N) to add N elements when the command is called with multiple arguments.",
+	})
+}
+
+type SaddCommand struct {
+	Key     string
+	Members []string
+}
+
+func (cmd *SaddCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	added := 0
+
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		s, err := getOrCreateSet(tx, cmd.Key)
+		if err != nil {
+			return err
+		}
+
+		for _, member := range cmd.Members {
+			if s.Add(member) {
+				added++
+			}
+		}
+
+		if added > 0 {
+			inst.Propagate(append([]string{"SADD", cmd.Key}, cmd.Members...))
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.Integer(int64(added))
+	return nil
+}
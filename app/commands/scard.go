@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       SCARD,
+		Arity:      2,
+		Flags:      []string{FlagReadonly, FlagFast},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &ScardCommand{args[0]}, nil },
+		Summary:    "Returns the number of members in a set.",
+		Since:      "1.0.0",
+		Group:      GroupSet,
+		Complexity: "O(1)",
+	})
+}
+
+type ScardCommand struct {
+	Key string
+}
+
+func (cmd *ScardCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	n := 0
+
+	err := viewSet(inst, cmd.Key, func(s *instance.Set) {
+		if s != nil {
+			n = s.Len()
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.Integer(int64(n))
+	return nil
+}
@@ -10,43 +10,131 @@ import (
 	"github.com/codecrafters-io/redis-starter-go/app/instance"
 )
 
+func init() {
+	register(&Spec{
+		Name:       SET,
+		Arity:      -3,
+		Flags:      []string{FlagWrite, FlagDenyOOM},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return NewSetCommand(args) },
+		Summary:    "Sets the string value of a key, ignoring its type. The key is created if it doesn't exist.",
+		Since:      "1.0.0",
+		Group:      GroupString,
+		Complexity: "O(1)",
+	})
+}
+
+// SetCommand implements SET key value [NX | XX] [GET] [EX seconds |
+// PX milliseconds | EXAT unix-time-seconds | PXAT unix-time-milliseconds |
+// KEEPTTL]. ExpireAt is zero if the value does not expire.
 type SetCommand struct {
-	Key    string
-	Value  string
-	Params []string
+	Key      string
+	Value    string
+	ExpireAt time.Time
+	KeepTTL  bool
+	NX       bool
+	XX       bool
+	Get      bool
 }
 
-func (cmd *SetCommand) Execute(inst *instance.Instance) ([]byte, error) {
-	if len(cmd.Params) == 0 {
-		fmt.Printf("Debug: set %s = %s\n", cmd.Key, cmd.Value)
-		inst.Store.Write(cmd.Key, cmd.Value, nil)
-		inst.Offset += cmd.Len()
-		return []byte("+OK\r\n"), nil
-	} else if len(cmd.Params) == 2 && strings.ToLower(cmd.Params[0]) == "px" {
-		fmt.Printf("Debug: set %s = %s, %s %s\n", cmd.Key, cmd.Value, cmd.Params[0], cmd.Params[1])
-		d, err := strconv.Atoi(cmd.Params[1])
-		if err != nil {
-			return nil, fmt.Errorf("Could not convert expiery length %s", cmd.Params[1])
+func NewSetCommand(args []string) (*SetCommand, error) {
+	cmd := &SetCommand{Key: args[0], Value: args[1]}
+	hasExpiry := false
+
+	for i := 2; i < len(args); i++ {
+		opt := strings.ToLower(args[i])
+
+		switch opt {
+		case "nx":
+			if cmd.XX {
+				return nil, ErrSyntax
+			}
+			cmd.NX = true
+		case "xx":
+			if cmd.NX {
+				return nil, ErrSyntax
+			}
+			cmd.XX = true
+		case "get":
+			cmd.Get = true
+		case "keepttl":
+			if hasExpiry {
+				return nil, ErrSyntax
+			}
+			cmd.KeepTTL = true
+		case "ex", "px", "exat", "pxat":
+			if hasExpiry || cmd.KeepTTL || i+1 >= len(args) {
+				return nil, ErrSyntax
+			}
+
+			expireAt, err := parseExpireTime(opt, args[i+1], time.Now())
+			if err != nil {
+				if err == errInvalidExpire {
+					return nil, errorf("invalid expire time in 'set' command")
+				}
+				return nil, err
+			}
+
+			cmd.ExpireAt = expireAt
+			hasExpiry = true
+			i++
+		default:
+			return nil, ErrSyntax
 		}
-		var dur *time.Duration
-		dur = new(time.Duration)
-		*dur = time.Duration(d) * time.Millisecond
-		inst.Store.Write(cmd.Key, cmd.Value, dur)
-		inst.Offset += cmd.Len()
-		return []byte("+OK\r\n"), nil
 	}
 
-	return nil, fmt.Errorf("Set command: Unknown parameters for set %s", cmd.Params)
+	return cmd, nil
 }
 
-func (cmd *SetCommand) Len() int {
-	return len(cmd.Encode())
-}
+func (cmd *SetCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	fmt.Printf("Debug: set %s = %s\n", cmd.Key, cmd.Value)
+
+	opts := instance.SetOptions{ExpireAt: cmd.ExpireAt, KeepTTL: cmd.KeepTTL, NX: cmd.NX, XX: cmd.XX, Get: cmd.Get}
+
+	var prev instance.Value
+	var existed, written bool
+
+	inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		prev, existed, written = tx.Set(cmd.Key, cmd.Value, opts)
+		if written {
+			inst.Propagate(cmd.Propagated())
+		}
+		return nil
+	})
+
+	if cmd.Get && existed && prev.Kind != instance.KindString {
+		return ErrWrongType
+	}
 
-func (cmd *SetCommand) Encode() []byte {
-	if len(cmd.Params) == 0 {
-		return encode.EncodeArray([]string{"SET", cmd.Key, cmd.Value})
+	if cmd.Get {
+		if existed {
+			w.Bulk(prev.Value)
+		} else {
+			w.NullBulk()
+		}
+	} else if written {
+		w.SimpleString("OK")
 	} else {
-		return encode.EncodeArray([]string{"SET", cmd.Key, cmd.Value, cmd.Params[0], cmd.Params[1]})
+		w.NullBulk()
+	}
+
+	return nil
+}
+
+// Propagated returns the command replicas have to apply. Relative expiry
+// times are sent as absolute PXAT, so replicas expire the key at the same
+// time no matter when they receive it. NX, XX and GET have no effect on
+// replicas, as the master only propagates writes that took place.
+func (cmd *SetCommand) Propagated() []string {
+	args := []string{"SET", cmd.Key, cmd.Value}
+
+	if !cmd.ExpireAt.IsZero() {
+		args = append(args, "PXAT", strconv.FormatInt(cmd.ExpireAt.UnixMilli(), 10))
+	} else if cmd.KeepTTL {
+		args = append(args, "KEEPTTL")
 	}
+
+	return args
 }
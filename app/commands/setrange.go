@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:     SETRANGE,
+		Arity:    4,
+		Flags:    []string{FlagWrite, FlagDenyOOM},
+		FirstKey: 1,
+		LastKey:  1,
+		Step:     1,
+		Create: func(args []string) (Command, error) {
+			offset, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return nil, ErrNotInteger
+			}
+
+			if offset < 0 {
+				return nil, errorf("offset is out of range")
+			}
+
+			return &SetrangeCommand{args[0], offset, args[2]}, nil
+		},
+		Summary:    "Overwrites a part of a string value with another by an offset. Creates the key if it doesn't exist.",
+		Since:      "2.2.0",
+		Group:      GroupString,
+		Complexity: "O(1), not counting the time taken to copy the new string in place. Usually, this string is very small so the amortized complexity is O(1). Otherwise, complexity is O(M) with M being the length of the value argument.",
+	})
+}
+
+// SetrangeCommand implements SETRANGE. A string shorter than Offset is padded
+// with zero bytes, an empty Value leaves the key untouched.
+type SetrangeCommand struct {
+	Key    string
+	Offset int64
+	Value  string
+}
+
+func (cmd *SetrangeCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var n int
+
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		v, _, err := getString(tx, cmd.Key)
+		if err != nil {
+			return err
+		}
+		n = len(v.Value)
+
+		if len(cmd.Value) == 0 {
+			return nil
+		}
+
+		if err := checkStringLength(cmd.Offset, len(cmd.Value)); err != nil {
+			return err
+		}
+
+		offset := int(cmd.Offset)
+		var b strings.Builder
+		b.Grow(max(len(v.Value), offset+len(cmd.Value)))
+
+		if offset <= len(v.Value) {
+			b.WriteString(v.Value[:offset])
+		} else {
+			b.WriteString(v.Value)
+			b.WriteString(strings.Repeat("\x00", offset-len(v.Value)))
+		}
+
+		b.WriteString(cmd.Value)
+		if end := offset + len(cmd.Value); end < len(v.Value) {
+			b.WriteString(v.Value[end:])
+		}
+
+		v.Value = b.String()
+		n = len(v.Value)
+		tx.Put(cmd.Key, v)
+		inst.Propagate([]string{"SETRANGE", cmd.Key, strconv.FormatInt(cmd.Offset, 10), cmd.Value})
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.Integer(int64(n))
+	return nil
+}
@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"slices"
+
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+// getSet returns the set stored at key, or nil if the key does not exist.
+// Keys holding another type are an error.
+func getSet(tx *instance.Tx, key string) (*instance.Set, error) {
+	v, ok := tx.Get(key)
+	if !ok {
+		return nil, nil
+	}
+
+	if v.Kind != instance.KindSet {
+		return nil, ErrWrongType
+	}
+	return v.Set, nil
+}
+
+// getOrCreateSet returns the set stored at key, creating an empty one if the
+// key does not exist.
+func getOrCreateSet(tx *instance.Tx, key string) (*instance.Set, error) {
+	s, err := getSet(tx, key)
+	if s != nil || err != nil {
+		return s, err
+	}
+
+	s = instance.NewSet()
+	tx.Put(key, instance.Value{Kind: instance.KindSet, Set: s})
+	return s, nil
+}
+
+// viewSet runs fn with the set stored at key, which is nil if the key does
+// not exist.
+func viewSet(inst *instance.Instance, key string, fn func(s *instance.Set)) error {
+	return inst.Store.View([]string{key}, func(tx *instance.Tx) error {
+		s, err := getSet(tx, key)
+		if err != nil {
+			return err
+		}
+
+		fn(s)
+		return nil
+	})
+}
+
+// getSets returns the sets stored at keys, nil for keys that do not exist.
+func getSets(tx *instance.Tx, keys []string) ([]*instance.Set, error) {
+	sets := make([]*instance.Set, len(keys))
+	for i, key := range keys {
+		s, err := getSet(tx, key)
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = s
+	}
+	return sets, nil
+}
+
+// setOp is an operation of set algebra.
+type setOp int
+
+const (
+	setInter setOp = iota
+	setUnion
+	setDiff
+)
+
+// combineSets applies op to sets, where nil counts as an empty set. The
+// difference is the one of the first set and all others.
+func combineSets(op setOp, sets []*instance.Set) *instance.Set {
+	result := instance.NewSet()
+
+	switch op {
+	case setInter:
+		rangeInter(sets, func(member string) bool {
+			result.Add(member)
+			return true
+		})
+
+	case setUnion:
+		for _, s := range sets {
+			if s != nil {
+				s.Range(func(member string) bool {
+					result.Add(member)
+					return true
+				})
+			}
+		}
+
+	case setDiff:
+		if sets[0] == nil {
+			return result
+		}
+
+		sets[0].Range(func(member string) bool {
+			for _, s := range sets[1:] {
+				if s != nil && s.Contains(member) {
+					return true
+				}
+			}
+			result.Add(member)
+			return true
+		})
+	}
+
+	return result
+}
+
+// rangeInter calls fn for the members of the intersection of sets until fn
+// returns false.
+func rangeInter(sets []*instance.Set, fn func(member string) bool) {
+	if slices.Contains(sets, nil) {
+		return
+	}
+
+	// Go through the smallest set, which bounds the result
+	sets = slices.Clone(sets)
+	slices.SortFunc(sets, func(a, b *instance.Set) int { return a.Len() - b.Len() })
+
+	sets[0].Range(func(member string) bool {
+		if containedInAll(sets[1:], member) {
+			return fn(member)
+		}
+		return true
+	})
+}
+
+// intersectCard returns the cardinality of the intersection of sets, but at
+// most limit unless it is 0.
+func intersectCard(sets []*instance.Set, limit int) int {
+	n := 0
+	rangeInter(sets, func(string) bool {
+		n++
+		return limit == 0 || n < limit
+	})
+	return n
+}
+
+// containedInAll reports whether member is contained in all of sets.
+func containedInAll(sets []*instance.Set, member string) bool {
+	for _, s := range sets {
+		if !s.Contains(member) {
+			return false
+		}
+	}
+	return true
+}
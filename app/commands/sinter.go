@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	setop := func(name string, op setOp, store bool, summary string, complexity string) *Spec {
+		spec := &Spec{
+			Name:     name,
+			Arity:    -2,
+			Flags:    []string{FlagReadonly},
+			FirstKey: 1,
+			LastKey:  -1,
+			Step:     1,
+			Create: func(args []string) (Command, error) {
+				cmd := &SetOpCommand{Name: name, Op: op, Keys: args}
+				if store {
+					cmd.Destination, cmd.Keys = args[0], args[1:]
+				}
+				return cmd, nil
+			},
+			Summary:    summary,
+			Since:      "1.0.0",
+			Group:      GroupSet,
+			Complexity: complexity,
+		}
+
+		if store {
+			spec.Arity = -3
+			spec.Flags = []string{FlagWrite, FlagDenyOOM}
+		}
+		return spec
+	}
+
+	register(setop(SINTER, setInter, false, "Returns the intersect of multiple sets.", "O(N*M) worst case where N is the cardinality of the smallest set and M is the number of sets."))
+	register(setop(SINTERSTORE, setInter, true, "Stores the intersect of multiple sets in a key.", "O(N*M) worst case where N is the cardinality of the smallest set and M is the number of sets."))
+	register(setop(SUNION, setUnion, false, "Returns the union of multiple sets.", "O(N) where N is the total number of elements in all given sets."))
+	register(setop(SUNIONSTORE, setUnion, true, "Stores the union of multiple sets in a key.", "O(N) where N is the total number of elements in all given sets."))
+	register(setop(SDIFF, setDiff, false, "Returns the difference of multiple sets.", "O(N) where N is the total number of elements in all given sets."))
+	register(setop(SDIFFSTORE, setDiff, true, "Stores the difference of multiple sets in a key.", "O(N) where N is the total number of elements in all given sets."))
+}
+
+// SetOpCommand implements SINTER, SUNION, SDIFF and their STORE variants,
+// which store the result at Destination instead of replying with it. An
+// empty result deletes Destination.
+type SetOpCommand struct {
+	Name        string
+	Op          setOp
+	Destination string
+	Keys        []string
+}
+
+func (cmd *SetOpCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	if cmd.Destination == "" {
+		return cmd.reply(inst, w)
+	}
+
+	n := 0
+
+	keys := append([]string{cmd.Destination}, cmd.Keys...)
+	err := inst.Store.Update(keys, func(tx *instance.Tx) error {
+		sets, err := getSets(tx, cmd.Keys)
+		if err != nil {
+			return err
+		}
+
+		result := combineSets(cmd.Op, sets)
+		n = result.Len()
+		if n == 0 {
+			tx.Delete(cmd.Destination)
+		} else {
+			tx.Put(cmd.Destination, instance.Value{Kind: instance.KindSet, Set: result})
+		}
+
+		inst.Propagate(append([]string{strings.ToUpper(cmd.Name)}, keys...))
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.Integer(int64(n))
+	return nil
+}
+
+// reply writes the result of the operation without storing it.
+func (cmd *SetOpCommand) reply(inst *instance.Instance, w *encode.Writer) error {
+	var members []string
+
+	err := inst.Store.View(cmd.Keys, func(tx *instance.Tx) error {
+		sets, err := getSets(tx, cmd.Keys)
+		if err != nil {
+			return err
+		}
+
+		members = combineSets(cmd.Op, sets).Members()
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	writeSet(w, members)
+	return nil
+}
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       SINTERCARD,
+		Arity:      -3,
+		Flags:      []string{FlagReadonly},
+		GetKeys:    numKeys,
+		Create:     func(args []string) (Command, error) { return NewSintercardCommand(args) },
+		Summary:    "Returns the number of members of the intersect of multiple sets.",
+		Since:      "7.0.0",
+		Group:      GroupSet,
+		Complexity: "O(N*M) worst case where N is the cardinality of the smallest set and M is the number of sets.",
+	})
+}
+
+// SintercardCommand implements SINTERCARD numkeys key [key ...] [LIMIT
+// limit]. Counting stops once Limit is reached, 0 means no limit.
+type SintercardCommand struct {
+	Keys  []string
+	Limit int
+}
+
+func NewSintercardCommand(args []string) (*SintercardCommand, error) {
+	keys, rest, err := parseNumKeys(args)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := &SintercardCommand{Keys: keys}
+
+	if len(rest) > 0 {
+		if len(rest) != 2 || strings.ToLower(rest[0]) != "limit" {
+			return nil, ErrSyntax
+		}
+
+		limit, err := strconv.Atoi(rest[1])
+		if err != nil {
+			return nil, ErrNotInteger
+		}
+		if limit < 0 {
+			return nil, errorf("LIMIT can't be negative")
+		}
+		cmd.Limit = limit
+	}
+
+	return cmd, nil
+}
+
+func (cmd *SintercardCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	n := 0
+
+	err := inst.Store.View(cmd.Keys, func(tx *instance.Tx) error {
+		sets, err := getSets(tx, cmd.Keys)
+		if err != nil {
+			return err
+		}
+
+		n = intersectCard(sets, cmd.Limit)
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.Integer(int64(n))
+	return nil
+}
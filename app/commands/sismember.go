@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       SISMEMBER,
+		Arity:      3,
+		Flags:      []string{FlagReadonly, FlagFast},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &SismemberCommand{Key: args[0], Members: args[1:2]}, nil },
+		Summary:    "Determines whether a member belongs to a set.",
+		Since:      "1.0.0",
+		Group:      GroupSet,
+		Complexity: "O(1)",
+	})
+	register(&Spec{
+		Name:     SMISMEMBER,
+		Arity:    -3,
+		Flags:    []string{FlagReadonly, FlagFast},
+		FirstKey: 1,
+		LastKey:  1,
+		Step:     1,
+		Create: func(args []string) (Command, error) {
+			return &SismemberCommand{Key: args[0], Members: args[1:], Multi: true}, nil
+		},
+		Summary:    "Determines whether multiple members belong to a set.",
+		Since:      "6.2.0",
+		Group:      GroupSet,
+		Complexity: "O(N) where N is the number of elements being checked for membership",
+	})
+}
+
+// SismemberCommand implements SISMEMBER and, with Multi set, SMISMEMBER,
+// which replies with an array instead of a single integer.
+type SismemberCommand struct {
+	Key     string
+	Members []string
+	Multi   bool
+}
+
+func (cmd *SismemberCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	found := make([]bool, len(cmd.Members))
+
+	err := viewSet(inst, cmd.Key, func(s *instance.Set) {
+		if s == nil {
+			return
+		}
+
+		for i, member := range cmd.Members {
+			found[i] = s.Contains(member)
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if cmd.Multi {
+		w.ArrayHeader(len(found))
+	}
+	for _, ok := range found {
+		if ok {
+			w.Integer(1)
+		} else {
+			w.Integer(0)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       SMEMBERS,
+		Arity:      2,
+		Flags:      []string{FlagReadonly},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &SmembersCommand{args[0]}, nil },
+		Summary:    "Returns all members of a set.",
+		Since:      "1.0.0",
+		Group:      GroupSet,
+		Complexity: "O(N) where N is the set cardinality.",
+	})
+}
+
+type SmembersCommand struct {
+	Key string
+}
+
+func (cmd *SmembersCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var members []string
+
+	err := viewSet(inst, cmd.Key, func(s *instance.Set) {
+		if s != nil {
+			members = s.Members()
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	writeSet(w, members)
+	return nil
+}
+
+// writeSet writes members as set reply.
+func writeSet(w *encode.Writer, members []string) {
+	w.SetHeader(len(members))
+	for _, member := range members {
+		w.Bulk(member)
+	}
+}
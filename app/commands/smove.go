@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       SMOVE,
+		Arity:      4,
+		Flags:      []string{FlagWrite, FlagFast},
+		FirstKey:   1,
+		LastKey:    2,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &SmoveCommand{args[0], args[1], args[2]}, nil },
+		Summary:    "Moves a member from one set to another.",
+		Since:      "1.0.0",
+		Group:      GroupSet,
+		Complexity: "O(1)",
+	})
+}
+
+type SmoveCommand struct {
+	Source      string
+	Destination string
+	Member      string
+}
+
+func (cmd *SmoveCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	found := false
+
+	err := inst.Store.Update([]string{cmd.Source, cmd.Destination}, func(tx *instance.Tx) error {
+		src, err := getSet(tx, cmd.Source)
+		if err != nil {
+			return err
+		}
+
+		// The destination has to be a set even if nothing is moved
+		if _, err := getSet(tx, cmd.Destination); err != nil {
+			return err
+		}
+
+		if src == nil || !src.Contains(cmd.Member) {
+			return nil
+		}
+
+		found = true
+		if cmd.Source == cmd.Destination {
+			return nil
+		}
+
+		src.Remove(cmd.Member)
+		if src.Len() == 0 {
+			tx.Delete(cmd.Source)
+		}
+
+		dst, _ := getOrCreateSet(tx, cmd.Destination)
+		dst.Add(cmd.Member)
+		inst.Propagate([]string{"SMOVE", cmd.Source, cmd.Destination, cmd.Member})
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if found {
+		w.Integer(1)
+	} else {
+		w.Integer(0)
+	}
+	return nil
+}
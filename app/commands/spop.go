@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"math/rand/v2"
+	"strconv"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:     SPOP,
+		Arity:    -2,
+		Flags:    []string{FlagWrite, FlagFast},
+		FirstKey: 1,
+		LastKey:  1,
+		Step:     1,
+		Create: func(args []string) (Command, error) {
+			cmd := &SpopCommand{Key: args[0], Count: -1}
+			if len(args) > 2 {
+				return nil, ErrSyntax
+			}
+
+			if len(args) == 2 {
+				count, err := strconv.ParseInt(args[1], 10, 64)
+				if err != nil {
+					return nil, ErrNotInteger
+				}
+				if count < 0 {
+					return nil, errorf("value is out of range, must be positive")
+				}
+				cmd.Count = count
+			}
+			return cmd, nil
+		},
+		Summary:    "Returns one or more random members from a set after removing them. Deletes the set if the last member was popped.",
+		Since:      "1.0.0",
+		Group:      GroupSet,
+		Complexity: "Without the count argument O(1), otherwise O(N) where N is the value of the passed count.",
+	})
+}
+
+// SpopCommand implements SPOP. Count is negative if it was not given, which
+// pops a single member and replies with it instead of a set. The popped
+// members are propagated as SREM.
+type SpopCommand struct {
+	Key   string
+	Count int64
+}
+
+func (cmd *SpopCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var popped []string
+
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		s, err := getSet(tx, cmd.Key)
+		if s == nil || cmd.Count == 0 {
+			return err
+		}
+
+		switch {
+		case cmd.Count < 0:
+			popped = []string{s.Random()}
+		case cmd.Count >= int64(s.Len()):
+			popped = s.Members()
+		default:
+			members := s.Members()
+			for i := range int(cmd.Count) {
+				j := i + rand.IntN(len(members)-i)
+				members[i], members[j] = members[j], members[i]
+			}
+			popped = members[:cmd.Count]
+		}
+
+		for _, member := range popped {
+			s.Remove(member)
+		}
+
+		if s.Len() == 0 {
+			tx.Delete(cmd.Key)
+		}
+
+		if len(popped) > 0 {
+			inst.Propagate(append([]string{"SREM", cmd.Key}, popped...))
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if cmd.Count >= 0 {
+		writeSet(w, popped)
+	} else if len(popped) == 0 {
+		w.NullBulk()
+	} else {
+		w.Bulk(popped[0])
+	}
+	return nil
+}
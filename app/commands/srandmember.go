@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"math"
+	"math/rand/v2"
+	"strconv"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:     SRANDMEMBER,
+		Arity:    -2,
+		Flags:    []string{FlagReadonly},
+		FirstKey: 1,
+		LastKey:  1,
+		Step:     1,
+		Create: func(args []string) (Command, error) {
+			cmd := &SrandmemberCommand{Key: args[0]}
+			if len(args) > 2 {
+				return nil, ErrSyntax
+			}
+
+			if len(args) == 2 {
+				count, err := strconv.ParseInt(args[1], 10, 64)
+				if err != nil {
+					return nil, ErrNotInteger
+				}
+				if count < -math.MaxInt64/2 || count > math.MaxInt64/2 {
+					return nil, errorf("value is out of range")
+				}
+				cmd.HasCount = true
+				cmd.Count = count
+			}
+			return cmd, nil
+		},
+		Summary:    "Get one or multiple random members from a set",
+		Since:      "1.0.0",
+		Group:      GroupSet,
+		Complexity: "Without the count argument O(1), otherwise O(N) where N is the absolute value of the passed count.",
+	})
+}
+
+// SrandmemberCommand implements SRANDMEMBER. Like for HRANDFIELD, a positive
+// count returns distinct members, a negative count allows repetitions.
+type SrandmemberCommand struct {
+	Key      string
+	HasCount bool
+	Count    int64
+}
+
+func (cmd *SrandmemberCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var members []string
+
+	err := viewSet(inst, cmd.Key, func(s *instance.Set) {
+		switch {
+		case s == nil:
+		case !cmd.HasCount:
+			members = []string{s.Random()}
+		case cmd.Count != 0:
+			members = s.Members()
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if !cmd.HasCount {
+		if len(members) == 0 {
+			w.NullBulk()
+		} else {
+			w.Bulk(members[0])
+		}
+		return nil
+	}
+
+	// Members are picked while writing, as the count is up to the client
+	if cmd.Count < 0 {
+		n := -cmd.Count
+		if len(members) == 0 {
+			n = 0
+		}
+
+		w.ArrayHeader(int(n))
+		for range n {
+			w.Bulk(members[rand.IntN(len(members))])
+			if err := w.FlushLarge(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	rand.Shuffle(len(members), func(i, j int) { members[i], members[j] = members[j], members[i] })
+	w.Array(members[:min(int64(len(members)), cmd.Count)])
+	return nil
+}
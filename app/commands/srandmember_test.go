@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+	"github.com/codecrafters-io/redis-starter-go/app/parser"
+)
+
+func TestSrandmemberCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		count  string
+		len    int
+		unique bool
+	}{
+		{"positive", "2", 2, true},
+		{"positive above size", "10", 3, true},
+		{"negative", "-2", 2, false},
+		{"negative above size", "-10", 10, false},
+		{"zero", "0", 0, false},
+	}
+
+	// Sets of integers and of strings are encoded differently
+	for _, members := range [][]string{{"1", "2", "3"}, {"a", "b", "c"}} {
+		inst := instance.NewInstance()
+		execute(t, inst, encode.RESP2, append([]string{"SADD", "set"}, members...)...)
+
+		for _, tt := range tests {
+			t.Run(members[0]+"/"+tt.name, func(t *testing.T) {
+				reply := execute(t, inst, encode.RESP2, "SRANDMEMBER", "set", tt.count)
+				if reply.Type != parser.Array || len(reply.Array) != tt.len {
+					t.Fatalf("reply = %v, want an array of %d members", reply, tt.len)
+				}
+
+				seen := make(map[string]bool)
+				for _, v := range reply.Array {
+					if !slices.Contains(members, v.Str) {
+						t.Errorf("reply holds %q, which is not in the set", v.Str)
+					}
+					if tt.unique && seen[v.Str] {
+						t.Errorf("reply holds %q more than once", v.Str)
+					}
+					seen[v.Str] = true
+				}
+			})
+		}
+	}
+}
+
+func TestSrandmemberMissingKey(t *testing.T) {
+	inst := instance.NewInstance()
+
+	if reply := execute(t, inst, encode.RESP2, "SRANDMEMBER", "missing"); !reply.IsNull() {
+		t.Errorf("SRANDMEMBER missing = %v, want null", reply)
+	}
+
+	for _, count := range []string{"5", "-5"} {
+		reply := execute(t, inst, encode.RESP2, "SRANDMEMBER", "missing", count)
+		if reply.Type != parser.Array || len(reply.Array) != 0 {
+			t.Errorf("SRANDMEMBER missing %s = %v, want an empty array", count, reply)
+		}
+	}
+}
+
+// TestSrandmemberHugeCount checks that a count far larger than any reply the
+// client could take is written while picking members, and stops once the
+// connection fails.
+func TestSrandmemberHugeCount(t *testing.T) {
+	inst := instance.NewInstance()
+	execute(t, inst, encode.RESP2, "SADD", "set", "a")
+
+	cmd, err := CreateCommand("SRANDMEMBER", []string{"set", "-1000000000000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cmd.Execute(inst, encode.NewWriter(failingWriter{})); err != errClosed {
+		t.Errorf("Execute() error = %v, want %v", err, errClosed)
+	}
+}
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       SREM,
+		Arity:      -3,
+		Flags:      []string{FlagWrite, FlagFast},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &SremCommand{args[0], args[1:]}, nil },
+		Summary:    "Removes one or more members from a set. Deletes the set if the last member was removed.",
+		Since:      "1.0.0",
+		Group:      GroupSet,
+		Complexity: "O(N) where N is the number of members to be removed.",
+	})
+}
+
+type SremCommand struct {
+	Key     string
+	Members []string
+}
+
+func (cmd *SremCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	n := 0
+
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		s, err := getSet(tx, cmd.Key)
+		if s == nil {
+			return err
+		}
+
+		for _, member := range cmd.Members {
+			if s.Remove(member) {
+				n++
+			}
+		}
+
+		if s.Len() == 0 {
+			tx.Delete(cmd.Key)
+		}
+
+		if n > 0 {
+			inst.Propagate(append([]string{"SREM", cmd.Key}, cmd.Members...))
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.Integer(int64(n))
+	return nil
+}
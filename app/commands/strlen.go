@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       STRLEN,
+		Arity:      2,
+		Flags:      []string{FlagReadonly, FlagFast},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &StrlenCommand{args[0]}, nil },
+		Summary:    "Returns the length of a string value.",
+		Since:      "2.2.0",
+		Group:      GroupString,
+		Complexity: "O(1)",
+	})
+}
+
+type StrlenCommand struct {
+	Key string
+}
+
+func (cmd *StrlenCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	v, ok := inst.Store.Get(cmd.Key)
+	if ok && v.Kind != instance.KindString {
+		return ErrWrongType
+	}
+
+	w.Integer(int64(len(v.Value)))
+	return nil
+}
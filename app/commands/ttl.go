@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	ttl := func(name string, summary string, since string) *Spec {
+		return &Spec{
+			Name:     name,
+			Arity:    2,
+			Flags:    []string{FlagReadonly, FlagFast},
+			FirstKey: 1,
+			LastKey:  1,
+			Step:     1,
+			Create: func(args []string) (Command, error) {
+				cmd := &TtlCommand{Key: args[0]}
+				cmd.Millis = name == PTTL || name == PEXPIRETIME
+				cmd.Absolute = name == EXPIRETIME || name == PEXPIRETIME
+				return cmd, nil
+			},
+			Summary:    summary,
+			Since:      since,
+			Group:      GroupGeneric,
+			Complexity: "O(1)",
+		}
+	}
+
+	register(ttl(TTL, "Returns the expiration time in seconds of a key.", "1.0.0"))
+	register(ttl(PTTL, "Returns the expiration time in milliseconds of a key.", "2.6.0"))
+	register(ttl(EXPIRETIME, "Returns the expiration time of a key as a Unix timestamp.", "7.0.0"))
+	register(ttl(PEXPIRETIME, "Returns the expiration time of a key as a Unix milliseconds timestamp.", "7.0.0"))
+}
+
+// TtlCommand implements TTL, PTTL, EXPIRETIME and PEXPIRETIME. They reply -2
+// for missing keys and -1 for keys without expiry.
+type TtlCommand struct {
+	Key      string
+	Millis   bool
+	Absolute bool
+}
+
+func (cmd *TtlCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	v, ok := inst.Store.Get(cmd.Key)
+
+	if !ok {
+		w.Integer(-2)
+		return nil
+	}
+
+	if !v.HasExpiry() {
+		w.Integer(-1)
+		return nil
+	}
+
+	expireAt := v.ExpireAt
+
+	if cmd.Absolute {
+		if cmd.Millis {
+			w.Integer(expireAt.UnixMilli())
+		} else {
+			w.Integer(expireAt.Unix())
+		}
+		return nil
+	}
+
+	ttl := max(time.Until(expireAt).Milliseconds(), 0)
+	if cmd.Millis {
+		w.Integer(ttl)
+	} else {
+		// Round to the closest second, like Redis does
+		w.Integer((ttl + 500) / 1000)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       TYPE,
+		Arity:      2,
+		Flags:      []string{FlagReadonly, FlagFast},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &TypeCommand{args[0]}, nil },
+		Summary:    "Determines the type of value stored at a key.",
+		Since:      "1.0.0",
+		Group:      GroupGeneric,
+		Complexity: "O(1)",
+	})
+}
+
+type TypeCommand struct {
+	Key string
+}
+
+func (cmd *TypeCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	v, ok := inst.Store.Get(cmd.Key)
+	if !ok {
+		w.SimpleString("none")
+		return nil
+	}
+
+	w.SimpleString(v.Kind.String())
+	return nil
+}
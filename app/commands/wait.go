@@ -2,21 +2,54 @@ package commands
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
 	"github.com/codecrafters-io/redis-starter-go/app/instance"
 )
 
+func init() {
+	register(&Spec{
+		Name:  WAIT,
+		Arity: 3,
+		Flags: []string{FlagNoScript},
+		Create: func(args []string) (Command, error) {
+			replCnt, err := strconv.Atoi(args[0])
+			if err != nil {
+				return nil, ErrNotInteger
+			}
+
+			timeout, err := strconv.Atoi(args[1])
+			if err != nil {
+				return nil, ErrNotInteger
+			}
+
+			if timeout < 0 {
+				return nil, ErrNegativeTTL
+			}
+
+			return &WaitCommand{replCnt, timeout}, nil
+		},
+		Summary:    "Blocks until the asynchronous replication of all preceding write commands sent by the connection is completed.",
+		Since:      "3.0.0",
+		Group:      GroupGeneric,
+		Complexity: "O(1)",
+	})
+}
+
 type WaitCommand struct {
 	NumReplicas int
 	Timeout     int
 }
 
-func (cmd *WaitCommand) Execute(inst *instance.Instance) ([]byte, error) {
-	fmt.Printf("Wait command with offset %d\n", inst.Offset)
-	if inst.Offset == 0 {
+func (cmd *WaitCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	offset := inst.ReplOffset()
+	fmt.Printf("Wait command with offset %d\n", offset)
+	if offset == 0 {
 		fmt.Println("Master has not propagated any commands")
-		return []byte(fmt.Sprintf(":%d\r\n", inst.NumReplicas())), nil
+		w.Integer(int64(inst.NumReplicas()))
+		return nil
 	}
 
 	endTime := time.Now().Add(time.Duration(cmd.Timeout) * time.Millisecond)
@@ -30,14 +63,14 @@ func (cmd *WaitCommand) Execute(inst *instance.Instance) ([]byte, error) {
 		select {
 		case <-inst.AckChan:
 			if inst.GetAckCnt() >= cmd.NumReplicas {
-				return []byte(fmt.Sprintf(":%d\r\n", inst.GetAckCnt())), nil
+				w.Integer(int64(inst.GetAckCnt()))
+				return nil
 			}
 		case <-tick.C:
 			if time.Now().After(endTime) {
-				return []byte(fmt.Sprintf(":%d\r\n", inst.GetAckCnt())), nil
+				w.Integer(int64(inst.GetAckCnt()))
+				return nil
 			}
 		}
 	}
-
-	return []byte(fmt.Sprintf(":%d\r\n", inst.GetAckCnt())), nil
 }
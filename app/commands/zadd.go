@@ -0,0 +1,184 @@
+package commands
+
+import (
+	"math"
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       ZADD,
+		Arity:      -4,
+		Flags:      []string{FlagWrite, FlagDenyOOM, FlagFast},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return NewZaddCommand(args) },
+		Summary:    "Adds one or more members to a sorted set, or updates their scores. Creates the key if it doesn't exist.",
+		Since:      "1.2.0",
+		Group:      GroupSortedSet,
+		Complexity: "O(log(N)) for each item added, where N is the number of elements in the sorted set.",
+	})
+	register(&Spec{
+		Name:     ZINCRBY,
+		Arity:    4,
+		Flags:    []string{FlagWrite, FlagDenyOOM, FlagFast},
+		FirstKey: 1,
+		LastKey:  1,
+		Step:     1,
+		Create: func(args []string) (Command, error) {
+			delta, err := parseScore(args[1])
+			if err != nil {
+				return nil, err
+			}
+			return &ZaddCommand{Name: ZINCRBY, Args: args, Key: args[0], Incr: true, Items: []scored{{args[2], delta}}}, nil
+		},
+		Summary:    "Increments the score of a member in a sorted set.",
+		Since:      "1.2.0",
+		Group:      GroupSortedSet,
+		Complexity: "O(log(N)) where N is the number of elements in the sorted set.",
+	})
+}
+
+// ZaddCommand implements ZADD key [NX | XX] [GT | LT] [CH] [INCR] score
+// member [score member ...] and ZINCRBY, which is ZADD with INCR. With Incr
+// set, the score of the single item is added to the current score.
+type ZaddCommand struct {
+	Name  string
+	Args  []string
+	Key   string
+	NX    bool
+	XX    bool
+	GT    bool
+	LT    bool
+	CH    bool
+	Incr  bool
+	Items []scored
+}
+
+func NewZaddCommand(args []string) (*ZaddCommand, error) {
+	cmd := &ZaddCommand{Name: ZADD, Args: args, Key: args[0]}
+
+	flags := map[string]*bool{
+		"nx":   &cmd.NX,
+		"xx":   &cmd.XX,
+		"gt":   &cmd.GT,
+		"lt":   &cmd.LT,
+		"ch":   &cmd.CH,
+		"incr": &cmd.Incr,
+	}
+
+	// Flags come first, the first argument that is not a flag is a score
+	i := 1
+	for ; i < len(args); i++ {
+		flag, ok := flags[strings.ToLower(args[i])]
+		if !ok {
+			break
+		}
+		*flag = true
+	}
+
+	pairs := args[i:]
+	if len(pairs) == 0 || len(pairs)%2 != 0 {
+		return nil, ErrSyntax
+	}
+
+	if cmd.Incr && len(pairs) > 2 {
+		return nil, errorf("INCR option supports a single increment-element pair")
+	}
+
+	if cmd.NX && cmd.XX {
+		return nil, errorf("XX and NX options at the same time are not compatible")
+	}
+
+	if (cmd.GT && cmd.NX) || (cmd.LT && cmd.NX) || (cmd.GT && cmd.LT) {
+		return nil, errorf("GT, LT, and/or NX options at the same time are not compatible")
+	}
+
+	for j := 0; j < len(pairs); j += 2 {
+		score, err := parseScore(pairs[j])
+		if err != nil {
+			return nil, err
+		}
+		cmd.Items = append(cmd.Items, scored{pairs[j+1], score})
+	}
+
+	return cmd, nil
+}
+
+func (cmd *ZaddCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	added, changed := 0, 0
+	var score float64
+	skipped := false
+
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		z, err := getZSet(tx, cmd.Key)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range cmd.Items {
+			current, exists := 0.0, false
+			if z != nil {
+				current, exists = z.Score(item.Member)
+			}
+
+			if (exists && cmd.NX) || (!exists && cmd.XX) {
+				skipped = true
+				continue
+			}
+
+			score = item.Score
+			if cmd.Incr {
+				score += current
+				if math.IsNaN(score) {
+					return errorf("resulting score is not a number (NaN)")
+				}
+			}
+
+			if exists && ((cmd.GT && score <= current) || (cmd.LT && score >= current)) {
+				skipped = true
+				continue
+			}
+
+			if z == nil {
+				z, _ = getOrCreateZSet(tx, cmd.Key)
+			}
+
+			if !exists {
+				added++
+			} else if score != current {
+				changed++
+			}
+			z.Add(item.Member, score)
+		}
+
+		if added+changed > 0 {
+			inst.Propagate(append([]string{strings.ToUpper(cmd.Name)}, cmd.Args...))
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if added+changed > 0 {
+		inst.SignalReady(cmd.Key)
+	}
+
+	switch {
+	case cmd.Incr && skipped:
+		w.NullBulk()
+	case cmd.Incr:
+		w.Double(score)
+	case cmd.CH:
+		w.Integer(int64(added + changed))
+	default:
+		w.Integer(int64(added))
+	}
+	return nil
+}
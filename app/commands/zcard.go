@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       ZCARD,
+		Arity:      2,
+		Flags:      []string{FlagReadonly, FlagFast},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &ZcardCommand{args[0]}, nil },
+		Summary:    "Returns the number of members in a sorted set.",
+		Since:      "1.2.0",
+		Group:      GroupSortedSet,
+		Complexity: "O(1)",
+	})
+}
+
+type ZcardCommand struct {
+	Key string
+}
+
+func (cmd *ZcardCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	n := 0
+
+	err := viewZSet(inst, cmd.Key, func(z *instance.SortedSet) {
+		if z != nil {
+			n = z.Len()
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.Integer(int64(n))
+	return nil
+}
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:     ZCOUNT,
+		Arity:    4,
+		Flags:    []string{FlagReadonly, FlagFast},
+		FirstKey: 1,
+		LastKey:  1,
+		Step:     1,
+		Create: func(args []string) (Command, error) {
+			r, err := parseScoreRange(args[1], args[2])
+			if err != nil {
+				return nil, err
+			}
+			return &ZcountCommand{Key: args[0], Range: r}, nil
+		},
+		Summary:    "Returns the count of members in a sorted set that have scores within a range.",
+		Since:      "2.0.0",
+		Group:      GroupSortedSet,
+		Complexity: "O(log(N)) with N being the number of elements in the sorted set.",
+	})
+	register(&Spec{
+		Name:     ZLEXCOUNT,
+		Arity:    4,
+		Flags:    []string{FlagReadonly, FlagFast},
+		FirstKey: 1,
+		LastKey:  1,
+		Step:     1,
+		Create: func(args []string) (Command, error) {
+			r, err := parseLexRange(args[1], args[2])
+			if err != nil {
+				return nil, err
+			}
+			return &ZcountCommand{Key: args[0], Range: r}, nil
+		},
+		Summary:    "Returns the number of members in a sorted set within a lexicographical range.",
+		Since:      "2.8.9",
+		Group:      GroupSortedSet,
+		Complexity: "O(log(N)) with N being the number of elements in the sorted set.",
+	})
+}
+
+// ZcountCommand implements ZCOUNT and ZLEXCOUNT.
+type ZcountCommand struct {
+	Key   string
+	Range rankRange
+}
+
+func (cmd *ZcountCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	n := 0
+
+	err := viewZSet(inst, cmd.Key, func(z *instance.SortedSet) {
+		if z != nil {
+			start, end := cmd.Range.Ranks(z)
+			n = end - start
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.Integer(int64(n))
+	return nil
+}
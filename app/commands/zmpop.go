@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       ZMPOP,
+		Arity:      -4,
+		Flags:      []string{FlagWrite},
+		GetKeys:    numKeys,
+		Create:     func(args []string) (Command, error) { return NewZmpopCommand(args) },
+		Summary:    "Returns the highest- or lowest-scoring members from one or more sorted sets after removing them. Deletes the sorted set if the last member was popped.",
+		Since:      "7.0.0",
+		Group:      GroupSortedSet,
+		Complexity: "O(K) + O(M*log(N)) where K is the number of provided keys, N being the number of elements in the sorted set, and M being the number of elements popped.",
+	})
+}
+
+// ZmpopCommand implements ZMPOP numkeys key [key ...] MIN | MAX [COUNT
+// count]. Members are popped from the first non-empty sorted set.
+type ZmpopCommand struct {
+	Keys  []string
+	Max   bool
+	Count int64
+}
+
+func NewZmpopCommand(args []string) (*ZmpopCommand, error) {
+	keys, rest, err := parseNumKeys(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rest) == 0 {
+		return nil, ErrSyntax
+	}
+
+	cmd := &ZmpopCommand{Keys: keys, Count: 1}
+
+	switch strings.ToLower(rest[0]) {
+	case "min":
+	case "max":
+		cmd.Max = true
+	default:
+		return nil, ErrSyntax
+	}
+
+	if len(rest) > 1 {
+		if len(rest) != 3 || strings.ToLower(rest[1]) != "count" {
+			return nil, ErrSyntax
+		}
+
+		count, err := parseInt(rest[2])
+		if err != nil || count <= 0 {
+			return nil, errorf("count should be greater than 0")
+		}
+		cmd.Count = count
+	}
+
+	return cmd, nil
+}
+
+func (cmd *ZmpopCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var key string
+	var items []scored
+
+	err := inst.Store.Update(cmd.Keys, func(tx *instance.Tx) error {
+		var err error
+		key, items, err = popFirstZSet(tx, cmd.Keys, cmd.Max, cmd.Count)
+		if items != nil {
+			propagateZpop(inst, key, cmd.Max, len(items))
+		}
+		return err
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if items == nil {
+		w.NullArray()
+		return nil
+	}
+
+	writeZmpop(w, key, items)
+	return nil
+}
+
+// popFirstZSet pops up to count members from the first of keys holding a
+// sorted set. It returns no members if all sorted sets are empty.
+func popFirstZSet(tx *instance.Tx, keys []string, highest bool, count int64) (string, []scored, error) {
+	for _, key := range keys {
+		z, err := getZSet(tx, key)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if z != nil {
+			return key, popZSet(tx, key, z, highest, count), nil
+		}
+	}
+
+	return "", nil, nil
+}
+
+// propagateZpop propagates popping n members from key.
+func propagateZpop(inst *instance.Instance, key string, highest bool, n int) {
+	name := "ZPOPMIN"
+	if highest {
+		name = "ZPOPMAX"
+	}
+	inst.Propagate([]string{name, key, strconv.Itoa(n)})
+}
+
+// writeZmpop writes the reply of ZMPOP and BZMPOP, the key followed by the
+// popped members and their scores.
+func writeZmpop(w *encode.Writer, key string, items []scored) {
+	w.ArrayHeader(2)
+	w.Bulk(key)
+
+	w.ArrayHeader(len(items))
+	for _, item := range items {
+		w.ArrayHeader(2)
+		w.Bulk(item.Member)
+		w.Double(item.Score)
+	}
+}
@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	zpop := func(name string, summary string) *Spec {
+		return &Spec{
+			Name:     name,
+			Arity:    -2,
+			Flags:    []string{FlagWrite, FlagFast},
+			FirstKey: 1,
+			LastKey:  1,
+			Step:     1,
+			Create: func(args []string) (Command, error) {
+				cmd := &ZpopCommand{Key: args[0], Max: name == ZPOPMAX, Count: 1}
+				if len(args) > 2 {
+					return nil, ErrSyntax
+				}
+
+				if len(args) == 2 {
+					count, err := parseInt(args[1])
+					if err != nil {
+						return nil, err
+					}
+					if count < 0 {
+						return nil, errorf("value is out of range, must be positive")
+					}
+					cmd.Count = count
+					cmd.HasCount = true
+				}
+				return cmd, nil
+			},
+			Summary:    summary,
+			Since:      "5.0.0",
+			Group:      GroupSortedSet,
+			Complexity: "O(log(N)*M) with N being the number of elements in the sorted set, and M being the number of elements popped.",
+		}
+	}
+
+	register(zpop(ZPOPMIN, "Returns the lowest-scoring members from a sorted set after removing them. Deletes the sorted set if the last member was popped."))
+	register(zpop(ZPOPMAX, "Returns the highest-scoring members from a sorted set after removing them. Deletes the sorted set if the last member was popped."))
+}
+
+// ZpopCommand implements ZPOPMIN and ZPOPMAX. Without a count, the reply is
+// a flat array of member and score even for RESP3 clients.
+type ZpopCommand struct {
+	Key      string
+	Max      bool
+	Count    int64
+	HasCount bool
+}
+
+func (cmd *ZpopCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var items []scored
+
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		z, err := getZSet(tx, cmd.Key)
+		if z == nil {
+			return err
+		}
+
+		items = popZSet(tx, cmd.Key, z, cmd.Max, cmd.Count)
+		if len(items) > 0 {
+			propagateZpop(inst, cmd.Key, cmd.Max, len(items))
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if !cmd.HasCount {
+		w.ArrayHeader(2 * len(items))
+		for _, item := range items {
+			w.Bulk(item.Member)
+			w.Double(item.Score)
+		}
+		return nil
+	}
+
+	writeScored(w, items, true)
+	return nil
+}
+
+// popZSet removes up to count members with the lowest or, if highest is
+// set, highest scores from z and returns them. Sorted sets are never left
+// empty, the key is deleted along with the last member.
+func popZSet(tx *instance.Tx, key string, z *instance.SortedSet, highest bool, count int64) []scored {
+	var items []scored
+	collect := func(member string, score float64) bool {
+		items = append(items, scored{member, score})
+		return true
+	}
+
+	n := int(min(count, int64(z.Len())))
+	if highest {
+		z.ReverseRange(z.Len()-n, z.Len()-1, collect)
+	} else {
+		z.Range(0, n-1, collect)
+	}
+
+	for _, item := range items {
+		z.Remove(item.Member)
+	}
+
+	if z.Len() == 0 {
+		tx.Delete(key)
+	}
+	return items
+}
@@ -0,0 +1,264 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	zrange := func(name string, arity int, summary string, since string) *Spec {
+		spec := &Spec{
+			Name:       name,
+			Arity:      arity,
+			Flags:      []string{FlagReadonly},
+			FirstKey:   1,
+			LastKey:    1,
+			Step:       1,
+			Create:     func(args []string) (Command, error) { return NewZrangeCommand(name, args) },
+			Summary:    summary,
+			Since:      since,
+			Group:      GroupSortedSet,
+			Complexity: "O(log(N)+M) with N being the number of elements in the sorted set and M the number of elements returned.",
+		}
+
+		if name == ZRANGESTORE {
+			spec.Flags = []string{FlagWrite, FlagDenyOOM}
+			spec.LastKey = 2
+		}
+		return spec
+	}
+
+	register(zrange(ZRANGE, -4, "Returns members in a sorted set within a range of indexes.", "1.2.0"))
+	register(zrange(ZRANGESTORE, -5, "Stores a range of members from sorted set in a key.", "6.2.0"))
+	register(zrange(ZREVRANGE, -4, "Returns members in a sorted set within a range of indexes in reverse order.", "1.2.0"))
+	register(zrange(ZRANGEBYSCORE, -4, "Returns members in a sorted set within a range of scores.", "1.0.5"))
+	register(zrange(ZREVRANGEBYSCORE, -4, "Returns members in a sorted set within a range of scores in reverse order.", "2.2.0"))
+	register(zrange(ZRANGEBYLEX, -4, "Returns members in a sorted set within a lexicographical range.", "2.8.9"))
+	register(zrange(ZREVRANGEBYLEX, -4, "Returns members in a sorted set within a lexicographical range in reverse order.", "2.8.9"))
+}
+
+// Kinds of ranges selected by zrangeQuery.
+const (
+	byRank = iota
+	byScore
+	byLex
+)
+
+// zrangeQuery selects members of a sorted set, by rank from Start to Stop
+// or by Range. Count is negative if there is no limit, which only applies
+// to ranges by score and lex.
+type zrangeQuery struct {
+	By            int
+	Rev           bool
+	Start, Stop   int64
+	Range         rankRange
+	Offset, Count int64
+}
+
+// ranks returns the inclusive range of ranks selected in z.
+func (q *zrangeQuery) ranks(z *instance.SortedSet) (int, int) {
+	n := z.Len()
+
+	if q.By == byRank {
+		start, end := listRange(q.Start, q.Stop, n)
+		if q.Rev {
+			start, end = n-1-end, n-1-start
+		}
+		return start, end
+	}
+
+	if q.Offset < 0 {
+		return 0, -1
+	}
+
+	start, end := q.Range.Ranks(z)
+	end--
+
+	// The limit applies in the direction of the query
+	offset := int(min(q.Offset, int64(n)))
+	if q.Rev {
+		end -= offset
+		if q.Count >= 0 {
+			start = max(start, end-int(min(q.Count, int64(n)))+1)
+		}
+	} else {
+		start += offset
+		if q.Count >= 0 {
+			end = min(end, start+int(min(q.Count, int64(n)))-1)
+		}
+	}
+	return start, end
+}
+
+// run returns the selected members in the order of the query.
+func (q *zrangeQuery) run(z *instance.SortedSet) []scored {
+	var items []scored
+	collect := func(member string, score float64) bool {
+		items = append(items, scored{member, score})
+		return true
+	}
+
+	start, end := q.ranks(z)
+	if q.Rev {
+		z.ReverseRange(start, end, collect)
+	} else {
+		z.Range(start, end, collect)
+	}
+	return items
+}
+
+// parseZrangeQuery parses the range arguments of the command name and its
+// options. Only ZRANGE and ZRANGESTORE choose the kind of range with
+// options, the other commands imply it.
+func parseZrangeQuery(name string, minArg string, maxArg string, opts []string) (*zrangeQuery, bool, error) {
+	q := &zrangeQuery{Count: -1}
+	withScores, limit := false, false
+
+	switch name {
+	case ZREVRANGE:
+		q.Rev = true
+	case ZRANGEBYSCORE:
+		q.By = byScore
+	case ZREVRANGEBYSCORE:
+		q.By, q.Rev = byScore, true
+	case ZRANGEBYLEX:
+		q.By = byLex
+	case ZREVRANGEBYLEX:
+		q.By, q.Rev = byLex, true
+	}
+
+	unified := name == ZRANGE || name == ZRANGESTORE
+	for i := 0; i < len(opts); i++ {
+		switch opt := strings.ToLower(opts[i]); {
+		case opt == "withscores" && name != ZRANGESTORE:
+			withScores = true
+		case opt == "byscore" && unified:
+			q.By = byScore
+		case opt == "bylex" && unified:
+			q.By = byLex
+		case opt == "rev" && unified:
+			q.Rev = true
+		case opt == "limit" && i+2 < len(opts):
+			var err error
+			if q.Offset, err = parseInt(opts[i+1]); err != nil {
+				return nil, false, err
+			}
+			if q.Count, err = parseInt(opts[i+2]); err != nil {
+				return nil, false, err
+			}
+			limit = true
+			i += 2
+		default:
+			return nil, false, ErrSyntax
+		}
+	}
+
+	if limit && q.By == byRank {
+		return nil, false, errorf("syntax error, LIMIT is only supported in combination with either BYSCORE or BYLEX")
+	}
+	if withScores && q.By == byLex {
+		return nil, false, errorf("syntax error, WITHSCORES not supported in combination with BYLEX")
+	}
+
+	// Reverse ranges by score and lex start at the maximum
+	if q.Rev && q.By != byRank {
+		minArg, maxArg = maxArg, minArg
+	}
+
+	var err error
+	switch q.By {
+	case byRank:
+		if q.Start, err = parseInt(minArg); err == nil {
+			q.Stop, err = parseInt(maxArg)
+		}
+	case byScore:
+		q.Range, err = parseScoreRange(minArg, maxArg)
+	case byLex:
+		q.Range, err = parseLexRange(minArg, maxArg)
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+	return q, withScores, nil
+}
+
+// ZrangeCommand implements ZRANGE with all its options, the older commands
+// it replaces, such as ZRANGEBYSCORE, and ZRANGESTORE, which stores the
+// result at Destination instead of replying with it.
+type ZrangeCommand struct {
+	Name        string
+	Args        []string
+	Destination string
+	Key         string
+	Query       *zrangeQuery
+	WithScores  bool
+}
+
+func NewZrangeCommand(name string, args []string) (*ZrangeCommand, error) {
+	cmd := &ZrangeCommand{Name: name, Args: args}
+
+	if name == ZRANGESTORE {
+		cmd.Destination, args = args[0], args[1:]
+	}
+	cmd.Key = args[0]
+
+	var err error
+	if cmd.Query, cmd.WithScores, err = parseZrangeQuery(name, args[1], args[2], args[3:]); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+func (cmd *ZrangeCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	if cmd.Destination == "" {
+		var items []scored
+
+		err := viewZSet(inst, cmd.Key, func(z *instance.SortedSet) {
+			if z != nil {
+				items = cmd.Query.run(z)
+			}
+		})
+
+		if err != nil {
+			return err
+		}
+
+		writeScored(w, items, cmd.WithScores)
+		return nil
+	}
+
+	n := 0
+
+	err := inst.Store.Update([]string{cmd.Destination, cmd.Key}, func(tx *instance.Tx) error {
+		z, err := getZSet(tx, cmd.Key)
+		if err != nil {
+			return err
+		}
+
+		result := instance.NewSortedSet()
+		if z != nil {
+			for _, item := range cmd.Query.run(z) {
+				result.Add(item.Member, item.Score)
+			}
+		}
+
+		putZSet(tx, cmd.Destination, result)
+		n = result.Len()
+		inst.Propagate(append([]string{"ZRANGESTORE"}, cmd.Args...))
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if n > 0 {
+		inst.SignalReady(cmd.Destination)
+	}
+
+	w.Integer(int64(n))
+	return nil
+}
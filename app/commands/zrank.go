@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	zrank := func(name string, summary string) *Spec {
+		return &Spec{
+			Name:     name,
+			Arity:    -3,
+			Flags:    []string{FlagReadonly, FlagFast},
+			FirstKey: 1,
+			LastKey:  1,
+			Step:     1,
+			Create: func(args []string) (Command, error) {
+				if len(args) > 3 || (len(args) == 3 && !strings.EqualFold(args[2], "WITHSCORE")) {
+					return nil, ErrSyntax
+				}
+				return &ZrankCommand{Key: args[0], Member: args[1], Rev: name == ZREVRANK, WithScore: len(args) == 3}, nil
+			},
+			Summary:    summary,
+			Since:      "2.0.0",
+			Group:      GroupSortedSet,
+			Complexity: "O(log(N))",
+		}
+	}
+
+	register(zrank(ZRANK, "Returns the index of a member in a sorted set ordered by ascending scores."))
+	register(zrank(ZREVRANK, "Returns the index of a member in a sorted set ordered by descending scores."))
+}
+
+// ZrankCommand implements ZRANK and ZREVRANK, which counts ranks from the
+// highest score.
+type ZrankCommand struct {
+	Key       string
+	Member    string
+	Rev       bool
+	WithScore bool
+}
+
+func (cmd *ZrankCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	var rank int
+	var score float64
+	found := false
+
+	err := viewZSet(inst, cmd.Key, func(z *instance.SortedSet) {
+		if z == nil {
+			return
+		}
+
+		if rank, found = z.Rank(cmd.Member); found {
+			score, _ = z.Score(cmd.Member)
+			if cmd.Rev {
+				rank = z.Len() - 1 - rank
+			}
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case !found && cmd.WithScore:
+		w.NullArray()
+	case !found:
+		w.NullBulk()
+	case cmd.WithScore:
+		w.ArrayHeader(2)
+		w.Integer(int64(rank))
+		w.Double(score)
+	default:
+		w.Integer(int64(rank))
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       ZREM,
+		Arity:      -3,
+		Flags:      []string{FlagWrite, FlagFast},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &ZremCommand{args[0], args[1:]}, nil },
+		Summary:    "Removes one or more members from a sorted set. Deletes the sorted set if all members were removed.",
+		Since:      "1.2.0",
+		Group:      GroupSortedSet,
+		Complexity: "O(M*log(N)) with N being the number of elements in the sorted set and M the number of elements to be removed.",
+	})
+}
+
+type ZremCommand struct {
+	Key     string
+	Members []string
+}
+
+func (cmd *ZremCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	n := 0
+
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		z, err := getZSet(tx, cmd.Key)
+		if z == nil {
+			return err
+		}
+
+		for _, member := range cmd.Members {
+			if z.Remove(member) {
+				n++
+			}
+		}
+
+		if z.Len() == 0 {
+			tx.Delete(cmd.Key)
+		}
+
+		if n > 0 {
+			inst.Propagate(append([]string{"ZREM", cmd.Key}, cmd.Members...))
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.Integer(int64(n))
+	return nil
+}
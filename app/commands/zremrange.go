@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	// rangeName is the ZRANGE command that selects the same members
+	zremrange := func(name string, rangeName string, summary string, since string) *Spec {
+		return &Spec{
+			Name:     name,
+			Arity:    4,
+			Flags:    []string{FlagWrite},
+			FirstKey: 1,
+			LastKey:  1,
+			Step:     1,
+			Create: func(args []string) (Command, error) {
+				query, _, err := parseZrangeQuery(rangeName, args[1], args[2], nil)
+				if err != nil {
+					return nil, err
+				}
+				return &ZremrangeCommand{Name: name, Args: args, Key: args[0], Query: query}, nil
+			},
+			Summary:    summary,
+			Since:      since,
+			Group:      GroupSortedSet,
+			Complexity: "O(log(N)+M) with N being the number of elements in the sorted set and M the number of elements removed by the operation.",
+		}
+	}
+
+	register(zremrange(ZREMRANGEBYRANK, ZRANGE, "Removes members in a sorted set within a range of indexes. Deletes the sorted set if all members were removed.", "2.0.0"))
+	register(zremrange(ZREMRANGEBYSCORE, ZRANGEBYSCORE, "Removes members in a sorted set within a range of scores. Deletes the sorted set if all members were removed.", "1.2.0"))
+	register(zremrange(ZREMRANGEBYLEX, ZRANGEBYLEX, "Removes members in a sorted set within a lexicographical range. Deletes the sorted set if all members were removed.", "2.8.9"))
+}
+
+// ZremrangeCommand implements ZREMRANGEBYRANK, ZREMRANGEBYSCORE and
+// ZREMRANGEBYLEX, which select members like the respective ZRANGE commands.
+type ZremrangeCommand struct {
+	Name  string
+	Args  []string
+	Key   string
+	Query *zrangeQuery
+}
+
+func (cmd *ZremrangeCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	n := 0
+
+	err := inst.Store.Update([]string{cmd.Key}, func(tx *instance.Tx) error {
+		z, err := getZSet(tx, cmd.Key)
+		if z == nil {
+			return err
+		}
+
+		start, end := cmd.Query.ranks(z)
+		n = len(z.RemoveRange(start, end))
+		if z.Len() == 0 {
+			tx.Delete(cmd.Key)
+		}
+
+		if n > 0 {
+			inst.Propagate(append([]string{strings.ToUpper(cmd.Name)}, cmd.Args...))
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	w.Integer(int64(n))
+	return nil
+}
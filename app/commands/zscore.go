@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	register(&Spec{
+		Name:       ZSCORE,
+		Arity:      3,
+		Flags:      []string{FlagReadonly, FlagFast},
+		FirstKey:   1,
+		LastKey:    1,
+		Step:       1,
+		Create:     func(args []string) (Command, error) { return &ZscoreCommand{Key: args[0], Members: args[1:2]}, nil },
+		Summary:    "Returns the score of a member in a sorted set.",
+		Since:      "1.2.0",
+		Group:      GroupSortedSet,
+		Complexity: "O(1)",
+	})
+	register(&Spec{
+		Name:     ZMSCORE,
+		Arity:    -3,
+		Flags:    []string{FlagReadonly, FlagFast},
+		FirstKey: 1,
+		LastKey:  1,
+		Step:     1,
+		Create: func(args []string) (Command, error) {
+			return &ZscoreCommand{Key: args[0], Members: args[1:], Multi: true}, nil
+		},
+		Summary:    "Returns the score of one or more members in a sorted set.",
+		Since:      "6.2.0",
+		Group:      GroupSortedSet,
+		Complexity: "O(N) where N is the number of members being requested.",
+	})
+}
+
+// ZscoreCommand implements ZSCORE and, with Multi set, ZMSCORE, which
+// replies with an array instead of a single score.
+type ZscoreCommand struct {
+	Key     string
+	Members []string
+	Multi   bool
+}
+
+func (cmd *ZscoreCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	scores := make([]float64, len(cmd.Members))
+	found := make([]bool, len(cmd.Members))
+
+	err := viewZSet(inst, cmd.Key, func(z *instance.SortedSet) {
+		if z == nil {
+			return
+		}
+
+		for i, member := range cmd.Members {
+			scores[i], found[i] = z.Score(member)
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if cmd.Multi {
+		w.ArrayHeader(len(scores))
+	}
+	for i, score := range scores {
+		if found[i] {
+			w.Double(score)
+		} else {
+			w.NullBulk()
+		}
+	}
+	return nil
+}
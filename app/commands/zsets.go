@@ -0,0 +1,173 @@
+package commands
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+var (
+	errScoreRange = errorf("min or max is not a float")
+	errLexRange   = errorf("min or max not valid string range item")
+)
+
+// getZSet returns the sorted set stored at key, or nil if the key does not
+// exist. Keys holding another type are an error.
+func getZSet(tx *instance.Tx, key string) (*instance.SortedSet, error) {
+	v, ok := tx.Get(key)
+	if !ok {
+		return nil, nil
+	}
+
+	if v.Kind != instance.KindZSet {
+		return nil, ErrWrongType
+	}
+	return v.ZSet, nil
+}
+
+// getOrCreateZSet returns the sorted set stored at key, creating an empty
+// one if the key does not exist.
+func getOrCreateZSet(tx *instance.Tx, key string) (*instance.SortedSet, error) {
+	z, err := getZSet(tx, key)
+	if z != nil || err != nil {
+		return z, err
+	}
+
+	z = instance.NewSortedSet()
+	tx.Put(key, instance.Value{Kind: instance.KindZSet, ZSet: z})
+	return z, nil
+}
+
+// viewZSet runs fn with the sorted set stored at key, which is nil if the
+// key does not exist.
+func viewZSet(inst *instance.Instance, key string, fn func(z *instance.SortedSet)) error {
+	return inst.Store.View([]string{key}, func(tx *instance.Tx) error {
+		z, err := getZSet(tx, key)
+		if err != nil {
+			return err
+		}
+
+		fn(z)
+		return nil
+	})
+}
+
+// putZSet stores the new sorted set z under key, replacing any previous
+// value, or deletes the key if z is empty.
+func putZSet(tx *instance.Tx, key string, z *instance.SortedSet) {
+	if z.Len() == 0 {
+		tx.Delete(key)
+	} else {
+		tx.Put(key, instance.Value{Kind: instance.KindZSet, ZSet: z})
+	}
+}
+
+// parseScore parses a score, which may be infinite but not NaN.
+func parseScore(arg string) (float64, error) {
+	score, err := strconv.ParseFloat(arg, 64)
+	if err != nil || math.IsNaN(score) {
+		return 0, ErrNotFloat
+	}
+	return score, nil
+}
+
+// parseScoreRange parses the min and max arguments of commands like ZCOUNT.
+// Bounds are inclusive unless prefixed with "(".
+func parseScoreRange(minArg string, maxArg string) (instance.ScoreRange, error) {
+	var r instance.ScoreRange
+	var err error
+
+	parse := func(arg string) (float64, bool, error) {
+		exclusive := strings.HasPrefix(arg, "(")
+		if exclusive {
+			arg = arg[1:]
+		}
+
+		f, err := strconv.ParseFloat(arg, 64)
+		if err != nil || math.IsNaN(f) {
+			return 0, false, errScoreRange
+		}
+		return f, exclusive, nil
+	}
+
+	if r.Min, r.MinExclusive, err = parse(minArg); err != nil {
+		return r, err
+	}
+	if r.Max, r.MaxExclusive, err = parse(maxArg); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// parseLexRange parses the min and max arguments of commands like
+// ZLEXCOUNT. Bounds start with "[" if inclusive and "(" if exclusive, or are
+// "-" and "+" for the lowest and highest possible member.
+func parseLexRange(minArg string, maxArg string) (instance.LexRange, error) {
+	parse := func(arg string) (instance.LexBound, error) {
+		switch {
+		case arg == "-":
+			return instance.LexBound{Inf: -1}, nil
+		case arg == "+":
+			return instance.LexBound{Inf: 1}, nil
+		case strings.HasPrefix(arg, "["):
+			return instance.LexBound{Value: arg[1:]}, nil
+		case strings.HasPrefix(arg, "("):
+			return instance.LexBound{Value: arg[1:], Exclusive: true}, nil
+		}
+		return instance.LexBound{}, errLexRange
+	}
+
+	var r instance.LexRange
+	var err error
+
+	if r.Min, err = parse(minArg); err != nil {
+		return r, err
+	}
+	if r.Max, err = parse(maxArg); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// rankRange is a range of members of a sorted set, either an
+// instance.ScoreRange or an instance.LexRange.
+type rankRange interface {
+	Ranks(z *instance.SortedSet) (int, int)
+}
+
+// scored is a member of a sorted set along with its score.
+type scored struct {
+	Member string
+	Score  float64
+}
+
+// writeScored writes items, with their scores if withScores is set. RESP3
+// clients get an array of member and score pairs, RESP2 clients a flat
+// array.
+func writeScored(w *encode.Writer, items []scored, withScores bool) {
+	switch {
+	case !withScores:
+		w.ArrayHeader(len(items))
+		for _, item := range items {
+			w.Bulk(item.Member)
+		}
+
+	case w.Proto == encode.RESP3:
+		w.ArrayHeader(len(items))
+		for _, item := range items {
+			w.ArrayHeader(2)
+			w.Bulk(item.Member)
+			w.Double(item.Score)
+		}
+
+	default:
+		w.ArrayHeader(2 * len(items))
+		for _, item := range items {
+			w.Bulk(item.Member)
+			w.Double(item.Score)
+		}
+	}
+}
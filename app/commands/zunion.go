@@ -0,0 +1,280 @@
+package commands
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
+	"github.com/codecrafters-io/redis-starter-go/app/instance"
+)
+
+func init() {
+	zsetop := func(name string, op setOp, store bool, summary string, since string, complexity string) *Spec {
+		spec := &Spec{
+			Name:       name,
+			Arity:      -3,
+			Flags:      []string{FlagReadonly},
+			GetKeys:    numKeys,
+			Create:     func(args []string) (Command, error) { return NewZsetOpCommand(name, op, store, args) },
+			Summary:    summary,
+			Since:      since,
+			Group:      GroupSortedSet,
+			Complexity: complexity,
+		}
+
+		if store {
+			spec.Arity = -4
+			spec.Flags = []string{FlagWrite, FlagDenyOOM}
+			spec.GetKeys = func(args []string) []string {
+				return append([]string{args[0]}, numKeys(args[1:])...)
+			}
+		}
+		return spec
+	}
+
+	register(zsetop(ZUNION, setUnion, false, "Returns the union of multiple sorted sets.", "6.2.0", "O(N)+O(M*log(M)) with N being the sum of the sizes of the input sorted sets, and M being the number of elements in the resulting sorted set."))
+	register(zsetop(ZUNIONSTORE, setUnion, true, "Stores the union of multiple sorted sets in a key.", "2.0.0", "O(N)+O(M log(M)) with N being the sum of the sizes of the input sorted sets, and M being the number of elements in the resulting sorted set."))
+	register(zsetop(ZINTER, setInter, false, "Returns the intersect of multiple sorted sets.", "6.2.0", "O(N*K)+O(M*log(M)) worst case with N being the smallest input sorted set, K being the number of input sorted sets and M being the number of elements in the resulting sorted set."))
+	register(zsetop(ZINTERSTORE, setInter, true, "Stores the intersect of multiple sorted sets in a key.", "2.0.0", "O(N*K)+O(M*log(M)) worst case with N being the smallest input sorted set, K being the number of input sorted sets and M being the number of elements in the resulting sorted set."))
+	register(zsetop(ZDIFF, setDiff, false, "Returns the difference between multiple sorted sets.", "6.2.0", "O(L + (N-K)log(N)) worst case where L is the total number of elements in all the sets, N is the size of the first set, and K is the size of the result set."))
+	register(zsetop(ZDIFFSTORE, setDiff, true, "Stores the difference of multiple sorted sets in a key.", "6.2.0", "O(L + (N-K)log(N)) worst case where L is the total number of elements in all the sets, N is the size of the first set, and K is the size of the result set."))
+}
+
+// Ways to combine the scores of a member found in several sorted sets.
+const (
+	aggregateSum = iota
+	aggregateMin
+	aggregateMax
+)
+
+// ZsetOpCommand implements ZUNION, ZINTER, ZDIFF and their STORE variants,
+// which store the result at Destination instead of replying with it. Plain
+// sets can be used as input, their members have a score of 1.
+type ZsetOpCommand struct {
+	Name        string
+	Args        []string
+	Op          setOp
+	Destination string
+	Keys        []string
+	Weights     []float64
+	Aggregate   int
+	WithScores  bool
+}
+
+func NewZsetOpCommand(name string, op setOp, store bool, args []string) (*ZsetOpCommand, error) {
+	cmd := &ZsetOpCommand{Name: name, Args: args, Op: op}
+
+	if store {
+		cmd.Destination, args = args[0], args[1:]
+	}
+
+	keys, rest, err := parseNumKeys(args)
+	if err != nil {
+		return nil, err
+	}
+	cmd.Keys = keys
+
+	cmd.Weights = make([]float64, len(keys))
+	for i := range cmd.Weights {
+		cmd.Weights[i] = 1
+	}
+
+	for i := 0; i < len(rest); i++ {
+		switch opt := strings.ToLower(rest[i]); {
+		case opt == "weights" && op != setDiff && i+len(keys) < len(rest):
+			for j := range keys {
+				w, err := strconv.ParseFloat(rest[i+1+j], 64)
+				if err != nil || math.IsNaN(w) {
+					return nil, errorf("weight value is not a float")
+				}
+				cmd.Weights[j] = w
+			}
+			i += len(keys)
+
+		case opt == "aggregate" && op != setDiff && i+1 < len(rest):
+			switch strings.ToLower(rest[i+1]) {
+			case "sum":
+				cmd.Aggregate = aggregateSum
+			case "min":
+				cmd.Aggregate = aggregateMin
+			case "max":
+				cmd.Aggregate = aggregateMax
+			default:
+				return nil, ErrSyntax
+			}
+			i++
+
+		case opt == "withscores" && !store:
+			cmd.WithScores = true
+
+		default:
+			return nil, ErrSyntax
+		}
+	}
+
+	return cmd, nil
+}
+
+func (cmd *ZsetOpCommand) Execute(inst *instance.Instance, w *encode.Writer) error {
+	if cmd.Destination == "" {
+		var items []scored
+
+		err := inst.Store.View(cmd.Keys, func(tx *instance.Tx) error {
+			result, err := cmd.combine(tx)
+			if err != nil {
+				return err
+			}
+
+			result.Range(0, result.Len()-1, func(member string, score float64) bool {
+				items = append(items, scored{member, score})
+				return true
+			})
+			return nil
+		})
+
+		if err != nil {
+			return err
+		}
+
+		writeScored(w, items, cmd.WithScores)
+		return nil
+	}
+
+	n := 0
+
+	keys := append([]string{cmd.Destination}, cmd.Keys...)
+	err := inst.Store.Update(keys, func(tx *instance.Tx) error {
+		result, err := cmd.combine(tx)
+		if err != nil {
+			return err
+		}
+
+		putZSet(tx, cmd.Destination, result)
+		n = result.Len()
+		inst.Propagate(append([]string{strings.ToUpper(cmd.Name)}, cmd.Args...))
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if n > 0 {
+		inst.SignalReady(cmd.Destination)
+	}
+
+	w.Integer(int64(n))
+	return nil
+}
+
+// combine applies the operation to the sorted sets stored at the keys.
+func (cmd *ZsetOpCommand) combine(tx *instance.Tx) (*instance.SortedSet, error) {
+	zsets := make([]*instance.SortedSet, len(cmd.Keys))
+	for i, key := range cmd.Keys {
+		v, ok := tx.Get(key)
+
+		switch {
+		case !ok:
+		case v.Kind == instance.KindZSet:
+			zsets[i] = v.ZSet
+		case v.Kind == instance.KindSet:
+			zsets[i] = instance.NewSortedSet()
+			v.Set.Range(func(member string) bool {
+				zsets[i].Add(member, 1)
+				return true
+			})
+		default:
+			return nil, ErrWrongType
+		}
+	}
+
+	scores := make(map[string]float64)
+
+	switch cmd.Op {
+	case setUnion:
+		for i, z := range zsets {
+			rangeZSet(z, func(member string, score float64) {
+				score = cmd.weigh(i, score)
+				if current, ok := scores[member]; ok {
+					score = cmd.aggregate(current, score)
+				}
+				scores[member] = score
+			})
+		}
+
+	case setInter:
+		for _, z := range zsets {
+			if z == nil {
+				return instance.NewSortedSet(), nil
+			}
+		}
+
+		rangeZSet(zsets[0], func(member string, score float64) {
+			score = cmd.weigh(0, score)
+			for i, z := range zsets[1:] {
+				other, ok := z.Score(member)
+				if !ok {
+					return
+				}
+				score = cmd.aggregate(score, cmd.weigh(i+1, other))
+			}
+			scores[member] = score
+		})
+
+	case setDiff:
+		rangeZSet(zsets[0], func(member string, score float64) {
+			for _, z := range zsets[1:] {
+				if z != nil {
+					if _, ok := z.Score(member); ok {
+						return
+					}
+				}
+			}
+			scores[member] = score
+		})
+	}
+
+	result := instance.NewSortedSet()
+	for member, score := range scores {
+		result.Add(member, score)
+	}
+	return result, nil
+}
+
+// weigh multiplies score with the weight of the i-th input. Like Redis, 0
+// is used if the product is not a number.
+func (cmd *ZsetOpCommand) weigh(i int, score float64) float64 {
+	score *= cmd.Weights[i]
+	if math.IsNaN(score) {
+		return 0
+	}
+	return score
+}
+
+func (cmd *ZsetOpCommand) aggregate(a float64, b float64) float64 {
+	switch cmd.Aggregate {
+	case aggregateMin:
+		return min(a, b)
+	case aggregateMax:
+		return max(a, b)
+	}
+
+	// Adding infinities of different sign results in 0 as well
+	if sum := a + b; !math.IsNaN(sum) {
+		return sum
+	}
+	return 0
+}
+
+// rangeZSet calls fn for all members of z, which may be nil.
+func rangeZSet(z *instance.SortedSet, fn func(member string, score float64)) {
+	if z == nil {
+		return
+	}
+
+	z.Range(0, z.Len()-1, func(member string, score float64) bool {
+		fn(member, score)
+		return true
+	})
+}
@@ -1,22 +1,149 @@
 package encode
 
 import (
-	"fmt"
+	"math"
 	"strconv"
 )
 
+const (
+	RESP2 = 2
+	RESP3 = 3
+)
+
 func EncodeBulkNoCrlf(str string) []byte {
-	return []byte("$" + strconv.Itoa(len(str)) + "\r\n" + str)
+	return appendBulkNoCrlf(nil, str)
 }
 
 func EncodeBulk(str string) []byte {
-	return []byte("$" + strconv.Itoa(len(str)) + "\r\n" + str + "\r\n")
+	return appendBulk(nil, str)
 }
 
 func EncodeArray(a []string) []byte {
-	msg := fmt.Sprintf("*%d\r\n", len(a))
+	size := 16
 	for _, e := range a {
-		msg = fmt.Sprintf("%s%s", msg, EncodeBulk(e))
+		size += len(e) + 16
 	}
-	return []byte(msg)
+
+	msg := appendHeader(make([]byte, 0, size), '*', len(a))
+	for _, e := range a {
+		msg = appendBulk(msg, e)
+	}
+	return msg
+}
+
+func EncodeSimpleString(str string) []byte {
+	return appendLine(nil, '+', str)
+}
+
+func EncodeError(msg string) []byte {
+	return appendLine(nil, '-', msg)
+}
+
+func EncodeInteger(n int64) []byte {
+	return appendInteger(nil, ':', n)
+}
+
+func EncodeArrayHeader(n int) []byte {
+	return appendHeader(nil, '*', n)
+}
+
+// EncodeNull encodes the RESP3 null type, RESP2 connections use null bulk
+// strings or null arrays instead.
+func EncodeNull() []byte {
+	return []byte("_\r\n")
+}
+
+func EncodeBoolean(b bool) []byte {
+	if b {
+		return []byte("#t\r\n")
+	}
+	return []byte("#f\r\n")
+}
+
+func EncodeDouble(f float64) []byte {
+	return appendLine(nil, ',', FormatDouble(f))
+}
+
+// EncodeBigNumber encodes an integer of arbitrary size, given in base 10.
+func EncodeBigNumber(num string) []byte {
+	return appendLine(nil, '(', num)
+}
+
+// EncodeVerbatim encodes a verbatim string, format is a three letter hint
+// such as "txt" or "mkd".
+func EncodeVerbatim(format string, str string) []byte {
+	return appendVerbatim(nil, format, str)
+}
+
+// EncodeMapHeader starts a map of n key value pairs, the 2n keys and values
+// have to follow.
+func EncodeMapHeader(n int) []byte {
+	return appendHeader(nil, '%', n)
+}
+
+func EncodeSetHeader(n int) []byte {
+	return appendHeader(nil, '~', n)
+}
+
+func EncodePushHeader(n int) []byte {
+	return appendHeader(nil, '>', n)
+}
+
+// EncodeAttributeHeader starts an attribute of n key value pairs, which is
+// followed by the value it describes.
+func EncodeAttributeHeader(n int) []byte {
+	return appendHeader(nil, '|', n)
+}
+
+// FormatDouble formats f the way Redis does, with the shortest
+// representation that parses back to f. Like %.17g, the exponent notation is
+// only used for very large and very small numbers.
+func FormatDouble(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	case math.IsNaN(f):
+		return "nan"
+	}
+
+	if abs := math.Abs(f); abs == 0 || (abs >= 1e-4 && abs < 1e17) {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return strconv.FormatFloat(f, 'e', -1, 64)
+}
+
+func appendLine(buf []byte, t byte, str string) []byte {
+	buf = append(buf, t)
+	buf = append(buf, str...)
+	return append(buf, '\r', '\n')
+}
+
+func appendInteger(buf []byte, t byte, n int64) []byte {
+	buf = append(buf, t)
+	buf = strconv.AppendInt(buf, n, 10)
+	return append(buf, '\r', '\n')
+}
+
+func appendHeader(buf []byte, t byte, n int) []byte {
+	return appendInteger(buf, t, int64(n))
+}
+
+func appendBulkNoCrlf(buf []byte, str string) []byte {
+	buf = appendHeader(buf, '$', len(str))
+	return append(buf, str...)
+}
+
+func appendBulk(buf []byte, str string) []byte {
+	buf = appendBulkNoCrlf(buf, str)
+	return append(buf, '\r', '\n')
+}
+
+func appendVerbatim(buf []byte, format string, str string) []byte {
+	buf = appendHeader(buf, '=', len(str)+4)
+	buf = append(buf, format...)
+	buf = append(buf, ':')
+	buf = append(buf, str...)
+	return append(buf, '\r', '\n')
 }
@@ -0,0 +1,192 @@
+package encode
+
+import (
+	"io"
+)
+
+// Writer encodes replies into a buffer, which is written to the connection
+// by Flush. Replies to a pipeline of commands are flushed together.
+//
+// Types that only exist in RESP3 are mapped to their RESP2 counterparts if
+// the connection did not switch to RESP3 with HELLO, the same way Redis does.
+type Writer struct {
+	w     io.Writer
+	buf   []byte
+	Proto int
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w, Proto: RESP2}
+}
+
+// Buffered returns the number of bytes not yet flushed.
+func (w *Writer) Buffered() int {
+	return len(w.buf)
+}
+
+// Flush writes the buffered replies to the underlying connection.
+func (w *Writer) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	_, err := w.w.Write(w.buf)
+	w.buf = w.buf[:0]
+	return err
+}
+
+// maxBuffered is the size past which FlushLarge flushes the buffer.
+const maxBuffered = 64 * 1024
+
+// FlushLarge flushes the buffer once it holds more than maxBuffered bytes.
+// Commands whose reply length depends on a client supplied count call it
+// while writing, so the buffer does not grow with the count.
+func (w *Writer) FlushLarge() error {
+	if len(w.buf) <= maxBuffered {
+		return nil
+	}
+	return w.Flush()
+}
+
+// Raw writes already encoded data.
+func (w *Writer) Raw(data []byte) {
+	w.buf = append(w.buf, data...)
+}
+
+func (w *Writer) SimpleString(str string) {
+	w.buf = appendLine(w.buf, '+', str)
+}
+
+// Error writes an error reply, msg has to start with an error prefix such
+// as ERR or WRONGTYPE.
+func (w *Writer) Error(msg string) {
+	w.buf = appendLine(w.buf, '-', msg)
+}
+
+func (w *Writer) Integer(n int64) {
+	w.buf = appendInteger(w.buf, ':', n)
+}
+
+func (w *Writer) Bulk(str string) {
+	w.buf = appendBulk(w.buf, str)
+}
+
+// NullBulk writes the reply for a missing string.
+func (w *Writer) NullBulk() {
+	if w.Proto == RESP3 {
+		w.buf = append(w.buf, "_\r\n"...)
+	} else {
+		w.buf = append(w.buf, "$-1\r\n"...)
+	}
+}
+
+// NullArray writes the reply for a missing array.
+func (w *Writer) NullArray() {
+	if w.Proto == RESP3 {
+		w.buf = append(w.buf, "_\r\n"...)
+	} else {
+		w.buf = append(w.buf, "*-1\r\n"...)
+	}
+}
+
+// ArrayHeader starts an array, the n elements have to follow.
+func (w *Writer) ArrayHeader(n int) {
+	w.buf = appendHeader(w.buf, '*', n)
+}
+
+// Array writes an array of bulk strings.
+func (w *Writer) Array(strs []string) {
+	w.ArrayHeader(len(strs))
+	for _, str := range strs {
+		w.Bulk(str)
+	}
+}
+
+// MapHeader starts a map of n key value pairs. On RESP2 connections maps are
+// sent as flat arrays of 2n elements.
+func (w *Writer) MapHeader(n int) {
+	if w.Proto == RESP3 {
+		w.buf = appendHeader(w.buf, '%', n)
+	} else {
+		w.buf = appendHeader(w.buf, '*', 2*n)
+	}
+}
+
+// SetHeader starts a set of n elements, RESP2 connections receive an array.
+func (w *Writer) SetHeader(n int) {
+	if w.Proto == RESP3 {
+		w.buf = appendHeader(w.buf, '~', n)
+	} else {
+		w.buf = appendHeader(w.buf, '*', n)
+	}
+}
+
+// PushHeader starts an out of band push message of n elements, RESP2
+// connections receive an array.
+func (w *Writer) PushHeader(n int) {
+	if w.Proto == RESP3 {
+		w.buf = appendHeader(w.buf, '>', n)
+	} else {
+		w.buf = appendHeader(w.buf, '*', n)
+	}
+}
+
+// AttributeHeader starts an attribute of n key value pairs, which is followed
+// by the value it describes. Attributes can not be expressed in RESP2, so
+// they must only be written to RESP3 connections.
+func (w *Writer) AttributeHeader(n int) {
+	w.buf = appendHeader(w.buf, '|', n)
+}
+
+// Null writes the RESP3 null, RESP2 connections receive a null bulk string.
+func (w *Writer) Null() {
+	w.NullBulk()
+}
+
+// Boolean writes a boolean, RESP2 connections receive the integers 1 and 0.
+func (w *Writer) Boolean(b bool) {
+	if w.Proto != RESP3 {
+		if b {
+			w.Integer(1)
+		} else {
+			w.Integer(0)
+		}
+		return
+	}
+
+	if b {
+		w.buf = append(w.buf, "#t\r\n"...)
+	} else {
+		w.buf = append(w.buf, "#f\r\n"...)
+	}
+}
+
+// Double writes a floating point number, RESP2 connections receive it as
+// bulk string.
+func (w *Writer) Double(f float64) {
+	if w.Proto == RESP3 {
+		w.buf = appendLine(w.buf, ',', FormatDouble(f))
+	} else {
+		w.Bulk(FormatDouble(f))
+	}
+}
+
+// BigNumber writes an integer of arbitrary size given in base 10, RESP2
+// connections receive it as bulk string.
+func (w *Writer) BigNumber(num string) {
+	if w.Proto == RESP3 {
+		w.buf = appendLine(w.buf, '(', num)
+	} else {
+		w.Bulk(num)
+	}
+}
+
+// Verbatim writes a string with a three letter format hint such as "txt",
+// RESP2 connections receive a plain bulk string.
+func (w *Writer) Verbatim(format string, str string) {
+	if w.Proto == RESP3 {
+		w.buf = appendVerbatim(w.buf, format, str)
+	} else {
+		w.Bulk(str)
+	}
+}
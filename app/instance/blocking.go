@@ -0,0 +1,171 @@
+package instance
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Waiter is a client blocked on one or more keys by a command such as
+// BLPOP. Waiters are served in the order they blocked, separately for each
+// key, but only while the key holds the kind of value they wait for.
+type Waiter struct {
+	inst   *Instance
+	keys   []string
+	kind   Kind
+	serve  func(key string) (bool, error)
+	served bool
+	err    error // Error of serve, which ends the wait as well
+	done   chan struct{}
+}
+
+// blocking keeps track of blocked clients. Keys signaled as ready are
+// collected separately, so that signaling does not have to wait for clients
+// being served.
+type blocking struct {
+	mu      sync.Mutex
+	waiters map[string][]*Waiter
+
+	readyMtx sync.Mutex
+	ready    []string
+}
+
+// Block calls serve for each of keys in turn, until it reports that it
+// served the client. If none of the keys is ready, the client is blocked
+// and serve is called again once a key holding a value of the given kind is
+// signaled as ready, by whichever client happens to serve blocked clients.
+// The returned waiter is nil if the client did not have to block.
+//
+// An error of serve is returned as is during the first attempt, later it
+// unblocks the client and is returned by Wait.
+func (inst *Instance) Block(keys []string, kind Kind, serve func(key string) (bool, error)) (*Waiter, error) {
+	b := &inst.blocking
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, key := range keys {
+		served, err := serve(key)
+		if err != nil || served {
+			return nil, err
+		}
+	}
+
+	w := &Waiter{inst: inst, keys: keys, kind: kind, serve: serve, done: make(chan struct{})}
+	if b.waiters == nil {
+		b.waiters = make(map[string][]*Waiter)
+	}
+	for _, key := range keys {
+		b.waiters[key] = append(b.waiters[key], w)
+	}
+	return w, nil
+}
+
+// Wait waits until the client is served, the timeout expires or cancel is
+// closed. A timeout of 0 waits forever. It reports whether the client was
+// served, or the error serving it failed with.
+func (w *Waiter) Wait(timeout time.Duration, cancel <-chan struct{}) (bool, error) {
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	select {
+	case <-w.done:
+		return true, w.err
+	case <-expired:
+	case <-cancel:
+	}
+
+	b := &w.inst.blocking
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// The client may have been served in the meantime
+	if w.served {
+		return true, w.err
+	}
+
+	b.remove(w)
+	return false, nil
+}
+
+// remove unregisters w from all its keys, the caller has to hold the lock.
+func (b *blocking) remove(w *Waiter) {
+	for _, key := range w.keys {
+		waiters := slices.DeleteFunc(b.waiters[key], func(other *Waiter) bool { return other == w })
+
+		if len(waiters) == 0 {
+			delete(b.waiters, key)
+		} else {
+			b.waiters[key] = waiters
+		}
+	}
+}
+
+// SignalReady marks key as possibly ready for blocked clients. Commands call
+// it after creating a value that blocked clients may consume, once the
+// command has been propagated.
+func (inst *Instance) SignalReady(key string) {
+	b := &inst.blocking
+	b.readyMtx.Lock()
+	b.ready = append(b.ready, key)
+	b.readyMtx.Unlock()
+}
+
+// ServeBlocked serves the clients blocked on keys signaled as ready, in the
+// order they blocked. A client that cannot be served does not hold up the
+// ones behind it. It is called after every command.
+func (inst *Instance) ServeBlocked() {
+	b := &inst.blocking
+
+	for {
+		b.readyMtx.Lock()
+		ready := b.ready
+		b.ready = nil
+		b.readyMtx.Unlock()
+
+		if len(ready) == 0 {
+			return
+		}
+
+		b.mu.Lock()
+		for _, key := range ready {
+			kind, ok := inst.kindOf(key)
+			if !ok {
+				continue
+			}
+
+			// Serving a client removes it from the queue, so iterate a copy
+			for _, w := range slices.Clone(b.waiters[key]) {
+				if w.kind != kind {
+					continue
+				}
+
+				served, err := w.serve(key)
+				if err == nil && !served {
+					continue
+				}
+
+				w.served = true
+				w.err = err
+				b.remove(w)
+				close(w.done)
+			}
+		}
+		b.mu.Unlock()
+	}
+}
+
+// kindOf returns the kind of the value stored at key, if any.
+func (inst *Instance) kindOf(key string) (Kind, bool) {
+	var v Value
+	var ok bool
+
+	inst.Store.View([]string{key}, func(tx *Tx) error {
+		v, ok = tx.Get(key)
+		return nil
+	})
+	return v.Kind, ok
+}
@@ -0,0 +1,201 @@
+package instance
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// pushList stores a list of elems at key and signals the key as ready.
+func pushList(inst *Instance, key string, elems ...string) {
+	inst.Store.Update([]string{key}, func(tx *Tx) error {
+		l := NewList()
+		for _, elem := range elems {
+			l.PushBack(elem)
+		}
+		tx.Put(key, Value{Kind: KindList, List: l})
+		return nil
+	})
+	inst.SignalReady(key)
+}
+
+// popper blocks on keys like BLPOP and records the element it popped.
+type popper struct {
+	waiter *Waiter
+	elem   string
+}
+
+func blockPop(t *testing.T, inst *Instance, kind Kind, keys ...string) *popper {
+	t.Helper()
+
+	p := &popper{}
+	w, err := inst.Block(keys, kind, func(key string) (bool, error) {
+		served := false
+		err := inst.Store.Update([]string{key}, func(tx *Tx) error {
+			v, ok := tx.Get(key)
+			if !ok || v.Kind != KindList {
+				return nil
+			}
+
+			p.elem, served = v.List.PopFront()
+			if v.List.Len() == 0 {
+				tx.Delete(key)
+			}
+			return nil
+		})
+		return served, err
+	})
+
+	if err != nil || w == nil {
+		t.Fatalf("Block() = %v, %v, want a waiter", w, err)
+	}
+	p.waiter = w
+	return p
+}
+
+// wait returns whether the client was served, without waiting for clients
+// that are not.
+func (p *popper) wait(t *testing.T) bool {
+	t.Helper()
+
+	served, err := p.waiter.Wait(10*time.Millisecond, nil)
+	if err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	return served
+}
+
+func TestBlockServedImmediately(t *testing.T) {
+	inst := NewInstance()
+	pushList(inst, "list", "a")
+
+	calls := 0
+	w, err := inst.Block([]string{"missing", "list"}, KindList, func(key string) (bool, error) {
+		calls++
+		return key == "list", nil
+	})
+
+	if w != nil || err != nil {
+		t.Errorf("Block() = %v, %v, want no waiter", w, err)
+	}
+	if calls != 2 {
+		t.Errorf("serve called %d times, want 2", calls)
+	}
+}
+
+func TestServeBlockedOrder(t *testing.T) {
+	inst := NewInstance()
+	first := blockPop(t, inst, KindList, "list")
+	second := blockPop(t, inst, KindList, "list")
+	third := blockPop(t, inst, KindList, "list")
+
+	pushList(inst, "list", "a", "b")
+	inst.ServeBlocked()
+
+	if !first.wait(t) || first.elem != "a" {
+		t.Errorf("first client served = %q, want \"a\"", first.elem)
+	}
+	if !second.wait(t) || second.elem != "b" {
+		t.Errorf("second client served = %q, want \"b\"", second.elem)
+	}
+	if third.wait(t) {
+		t.Errorf("third client served = %q, want not served", third.elem)
+	}
+}
+
+func TestServeBlockedKind(t *testing.T) {
+	inst := NewInstance()
+	zset := blockPop(t, inst, KindZSet, "key")
+	list := blockPop(t, inst, KindList, "key")
+
+	pushList(inst, "key", "a")
+	inst.ServeBlocked()
+
+	if zset.wait(t) {
+		t.Error("client waiting for a sorted set served from a list")
+	}
+	if !list.wait(t) || list.elem != "a" {
+		t.Errorf("client waiting for a list served = %q, want \"a\"", list.elem)
+	}
+}
+
+func TestServeBlockedMultipleKeys(t *testing.T) {
+	inst := NewInstance()
+	p := blockPop(t, inst, KindList, "one", "two")
+	other := blockPop(t, inst, KindList, "one")
+
+	pushList(inst, "two", "a")
+	pushList(inst, "one", "b")
+	inst.ServeBlocked()
+
+	if !p.wait(t) || p.elem != "a" {
+		t.Errorf("client served = %q, want \"a\"", p.elem)
+	}
+
+	// The served client no longer waits for the other key
+	if !other.wait(t) || other.elem != "b" {
+		t.Errorf("other client served = %q, want \"b\"", other.elem)
+	}
+}
+
+func TestServeBlockedSkipsUnserved(t *testing.T) {
+	inst := NewInstance()
+	w, err := inst.Block([]string{"list"}, KindList, func(key string) (bool, error) {
+		return false, nil
+	})
+	if err != nil || w == nil {
+		t.Fatalf("Block() = %v, %v, want a waiter", w, err)
+	}
+	p := blockPop(t, inst, KindList, "list")
+
+	pushList(inst, "list", "a")
+	inst.ServeBlocked()
+
+	if !p.wait(t) || p.elem != "a" {
+		t.Errorf("client behind an unserved one served = %q, want \"a\"", p.elem)
+	}
+	if served, _ := w.Wait(10*time.Millisecond, nil); served {
+		t.Error("client reported served although serve did not")
+	}
+}
+
+func TestServeBlockedError(t *testing.T) {
+	inst := NewInstance()
+	errServe := errors.New("serve failed")
+
+	w, err := inst.Block([]string{"list"}, KindList, func(key string) (bool, error) {
+		if _, ok := inst.kindOf(key); ok {
+			return false, errServe
+		}
+		return false, nil
+	})
+	if err != nil || w == nil {
+		t.Fatalf("Block() = %v, %v, want a waiter", w, err)
+	}
+
+	pushList(inst, "list", "a")
+	inst.ServeBlocked()
+
+	if served, err := w.Wait(10*time.Millisecond, nil); !served || err != errServe {
+		t.Errorf("Wait() = %v, %v, want true, %v", served, err, errServe)
+	}
+}
+
+func TestWaitCancel(t *testing.T) {
+	inst := NewInstance()
+	p := blockPop(t, inst, KindList, "list")
+
+	cancel := make(chan struct{})
+	close(cancel)
+	if served, err := p.waiter.Wait(0, cancel); served || err != nil {
+		t.Errorf("Wait() = %v, %v, want false, nil", served, err)
+	}
+
+	// A client that stopped waiting is not served anymore
+	pushList(inst, "list", "a")
+	inst.ServeBlocked()
+
+	if p.elem != "" {
+		t.Errorf("canceled client served = %q", p.elem)
+	}
+}
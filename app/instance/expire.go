@@ -0,0 +1,89 @@
+package instance
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// The active expire cycle follows the one of Redis: it runs ten times per
+// second and samples keys with an expiry, deleting the expired ones. As long
+// as more than a tenth of a sample has expired, another sample is taken, but
+// a cycle never runs longer than a quarter of its interval.
+const (
+	expireCycleInterval   = 100 * time.Millisecond
+	expireCycleTimeLimit  = expireCycleInterval / 4
+	expireCycleSampleSize = 20
+	expireCycleStalePct   = 10
+)
+
+// activeExpireSample deletes the expired keys and hash fields among a sample
+// of at most n keys with an expiry. It returns the number of keys sampled
+// and the number of keys that were expired or had expired fields.
+func (s *Store) activeExpireSample(sh *shard, n int) (int, int) {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	now := time.Now()
+	sampled, expired := 0, 0
+
+	// Map iteration starts at a random position, which makes for a cheap
+	// random sample
+	for key := range sh.expires {
+		if sampled == n {
+			break
+		}
+		sampled++
+
+		if s.expireDue(sh, key, now) {
+			expired++
+		} else if !sh.data[key].expires() {
+			// Expiries can be removed without going through put, e.g. by
+			// deleting a hash field, which leaves the key in the index
+			delete(sh.expires, key)
+		}
+	}
+
+	return sampled, expired
+}
+
+// ActiveExpireCycle goes through the shards, starting at a random one, and
+// deletes expired keys from each until few of the sampled keys turn out to
+// be expired. It stops early once the time limit is reached. A shard is
+// only locked for a single sample at a time, so clients are not blocked for
+// the whole cycle.
+func (s *Store) ActiveExpireCycle(limit time.Duration) int {
+	start := time.Now()
+	n := len(s.shards)
+	first := rand.IntN(n)
+	total := 0
+
+	for i := range n {
+		sh := &s.shards[(first+i)%n]
+
+		for {
+			sampled, expired := s.activeExpireSample(sh, expireCycleSampleSize)
+			total += expired
+
+			if time.Since(start) > limit {
+				return total
+			}
+
+			if sampled == 0 || expired*100 <= sampled*expireCycleStalePct {
+				break
+			}
+		}
+	}
+
+	return total
+}
+
+// ExpireLoop runs the active expire cycle periodically. It must only run on
+// a master, replicas wait for the DEL their master sends for expired keys.
+func (inst *Instance) ExpireLoop() {
+	ticker := time.NewTicker(expireCycleInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		inst.Store.ActiveExpireCycle(expireCycleTimeLimit)
+	}
+}
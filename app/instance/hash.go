@@ -0,0 +1,224 @@
+package instance
+
+import (
+	"maps"
+	"slices"
+	"time"
+)
+
+// Limits of the compact encoding of hashes, as hash-max-listpack-entries and
+// hash-max-listpack-value of Redis.
+const (
+	hashMaxCompactEntries = 128
+	hashMaxCompactValue   = 64
+)
+
+// Hash maps fields to values. Small hashes are stored as a flat slice of
+// alternating fields and values, similar to the listpack encoding of Redis,
+// which is converted to a map once the hash has more than
+// hashMaxCompactEntries fields or a field or value is longer than
+// hashMaxCompactValue bytes. Hashes never convert back.
+//
+// Fields can have their own expiry, which is kept apart from the encoding.
+// next caches the earliest of them, so that checking for expired fields on
+// every access is cheap.
+type Hash struct {
+	pairs   []string
+	m       map[string]string
+	expires map[string]time.Time
+	next    time.Time
+}
+
+func NewHash() *Hash {
+	return &Hash{}
+}
+
+func (h *Hash) Len() int {
+	if h.m != nil {
+		return len(h.m)
+	}
+	return len(h.pairs) / 2
+}
+
+// find returns the position of field in the compact encoding, or -1.
+func (h *Hash) find(field string) int {
+	for i := 0; i < len(h.pairs); i += 2 {
+		if h.pairs[i] == field {
+			return i
+		}
+	}
+	return -1
+}
+
+func (h *Hash) Get(field string) (string, bool) {
+	if h.m != nil {
+		value, ok := h.m[field]
+		return value, ok
+	}
+
+	if i := h.find(field); i >= 0 {
+		return h.pairs[i+1], true
+	}
+	return "", false
+}
+
+// Set sets field to value and reports whether the field is new. Any expiry
+// of the field is removed, like a plain SET does for keys.
+func (h *Hash) Set(field string, value string) bool {
+	h.Persist(field)
+	return h.SetKeepTTL(field, value)
+}
+
+// SetKeepTTL is like Set, but keeps the expiry of the field.
+func (h *Hash) SetKeepTTL(field string, value string) bool {
+	if h.m == nil && (len(field) > hashMaxCompactValue || len(value) > hashMaxCompactValue) {
+		h.convert()
+	}
+
+	if h.m != nil {
+		_, exists := h.m[field]
+		h.m[field] = value
+		return !exists
+	}
+
+	if i := h.find(field); i >= 0 {
+		h.pairs[i+1] = value
+		return false
+	}
+
+	h.pairs = append(h.pairs, field, value)
+	if len(h.pairs)/2 > hashMaxCompactEntries {
+		h.convert()
+	}
+	return true
+}
+
+// convert switches to the map encoding.
+func (h *Hash) convert() {
+	h.m = make(map[string]string, len(h.pairs))
+	for i := 0; i < len(h.pairs); i += 2 {
+		h.m[h.pairs[i]] = h.pairs[i+1]
+	}
+	h.pairs = nil
+}
+
+// Delete removes field and reports whether it existed.
+func (h *Hash) Delete(field string) bool {
+	h.Persist(field)
+
+	if h.m != nil {
+		_, exists := h.m[field]
+		delete(h.m, field)
+		return exists
+	}
+
+	i := h.find(field)
+	if i < 0 {
+		return false
+	}
+
+	h.pairs = slices.Delete(h.pairs, i, i+2)
+	return true
+}
+
+// Range calls fn for all fields and their values until fn returns false.
+func (h *Hash) Range(fn func(field string, value string) bool) {
+	if h.m != nil {
+		for field, value := range h.m {
+			if !fn(field, value) {
+				return
+			}
+		}
+		return
+	}
+
+	for i := 0; i < len(h.pairs); i += 2 {
+		if !fn(h.pairs[i], h.pairs[i+1]) {
+			return
+		}
+	}
+}
+
+// Clone returns a deep copy of the hash.
+func (h *Hash) Clone() *Hash {
+	return &Hash{pairs: slices.Clone(h.pairs), m: maps.Clone(h.m), expires: maps.Clone(h.expires), next: h.next}
+}
+
+// ExpireAt returns the point in time field expires at, which is zero if the
+// field does not expire.
+func (h *Hash) ExpireAt(field string) time.Time {
+	return h.expires[field]
+}
+
+// Expire sets the point in time the existing field expires at, if cond
+// allows it. It reports whether the expiry was changed.
+func (h *Hash) Expire(field string, at time.Time, cond ExpireCondition) bool {
+	if !cond.allows(h.expires[field], at) {
+		return false
+	}
+
+	if h.expires == nil {
+		h.expires = make(map[string]time.Time)
+	}
+	prev := h.expires[field]
+	h.expires[field] = at
+
+	if h.next.IsZero() || at.Before(h.next) {
+		h.next = at
+	} else if prev.Equal(h.next) {
+		h.updateNext()
+	}
+	return true
+}
+
+// Persist removes the expiry of field and reports whether it had one.
+func (h *Hash) Persist(field string) bool {
+	at, ok := h.expires[field]
+	if !ok {
+		return false
+	}
+
+	delete(h.expires, field)
+	if at.Equal(h.next) {
+		h.updateNext()
+	}
+	return true
+}
+
+// updateNext recomputes the earliest expiry of all fields.
+func (h *Hash) updateNext() {
+	h.next = time.Time{}
+	for _, at := range h.expires {
+		if h.next.IsZero() || at.Before(h.next) {
+			h.next = at
+		}
+	}
+}
+
+// hasExpiringFields reports whether any field has an expiry.
+func (h *Hash) hasExpiringFields() bool {
+	return len(h.expires) > 0
+}
+
+// hasExpiredFields reports whether any field has expired at the given time.
+func (h *Hash) hasExpiredFields(now time.Time) bool {
+	return !h.next.IsZero() && !now.Before(h.next)
+}
+
+// expireFields deletes the fields that have expired at the given time and
+// returns them.
+func (h *Hash) expireFields(now time.Time) []string {
+	var fields []string
+	for field, at := range h.expires {
+		if !now.Before(at) {
+			fields = append(fields, field)
+			delete(h.expires, field)
+		}
+	}
+
+	for _, field := range fields {
+		h.Delete(field)
+	}
+	h.updateNext()
+	return fields
+}
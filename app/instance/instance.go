@@ -1,47 +1,126 @@
 package instance
 
 import (
-	"fmt"
 	"net"
+	"slices"
 	"sync"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
 )
 
+// Instance is the state shared by all connections. The replication state is
+// guarded by ReplMutex, the info fields by their own lock.
 type Instance struct {
-	Store     Store
-	Info      map[string]map[string]string
-	Replicas  []net.Conn
+	Store     *Store
+	replicas  []*replica
 	ReplMutex sync.RWMutex
 	Master    net.Conn
-	Offset    int
+
+	offset int64
+
+	infoMtx sync.RWMutex
+	info    map[string]map[string]string
 
 	ackMtx  sync.RWMutex
 	numAck  int
 	AckChan chan struct{}
+
+	blocking blocking
+}
+
+func NewInstance() *Instance {
+	return &Instance{
+		Store:   NewStore(),
+		info:    make(map[string]map[string]string),
+		AckChan: make(chan struct{}),
+	}
+}
+
+// GetInfo returns a field of an INFO section, or "" if it is not set.
+func (inst *Instance) GetInfo(section string, field string) string {
+	inst.infoMtx.RLock()
+	defer inst.infoMtx.RUnlock()
+	return inst.info[section][field]
+}
+
+func (inst *Instance) SetInfo(section string, field string, value string) {
+	inst.infoMtx.Lock()
+	defer inst.infoMtx.Unlock()
+
+	if inst.info[section] == nil {
+		inst.info[section] = make(map[string]string)
+	}
+	inst.info[section][field] = value
+}
+
+// Role returns "master" or "slave".
+func (inst *Instance) Role() string {
+	return inst.GetInfo("replication", "role")
+}
+
+// ReplOffset returns the replication offset. On a master it counts the
+// bytes propagated to replicas, on a replica the bytes processed from the
+// master.
+func (inst *Instance) ReplOffset() int64 {
+	inst.ReplMutex.RLock()
+	defer inst.ReplMutex.RUnlock()
+	return inst.offset
+}
+
+// AddReplOffset advances the replication offset of a replica by n bytes.
+func (inst *Instance) AddReplOffset(n int) {
+	inst.ReplMutex.Lock()
+	inst.offset += int64(n)
+	inst.ReplMutex.Unlock()
 }
 
 func (inst *Instance) NumReplicas() int {
 	inst.ReplMutex.Lock()
-	n := len(inst.Replicas)
+	n := len(inst.replicas)
 	inst.ReplMutex.Unlock()
 
 	return n
 }
 
-func (inst *Instance) GetReplicas() []net.Conn {
+// AddReplica starts propagating commands to conn.
+func (inst *Instance) AddReplica(conn net.Conn) {
+	r := newReplica(conn)
+
 	inst.ReplMutex.Lock()
-	conns := make([]net.Conn, len(inst.Replicas))
-	copy(conns, inst.Replicas)
+	inst.replicas = append(inst.replicas, r)
 	inst.ReplMutex.Unlock()
 
-	return conns
+	go r.run(inst)
 }
 
-func (inst *Instance) AddReplica(conn net.Conn) {
+func (inst *Instance) removeReplica(r *replica) {
 	inst.ReplMutex.Lock()
-	inst.Replicas = append(inst.Replicas, conn)
+	inst.replicas = slices.DeleteFunc(inst.replicas, func(other *replica) bool { return other == r })
 	inst.ReplMutex.Unlock()
 }
 
+// Propagate sends a write command to all replicas and advances the
+// replication offset by its size. Replicas only forward the stream of their
+// master, so this does nothing on a replica. Commands call it from within
+// Store.Update, so the order replicas apply writes to a key in is the order
+// the master applied them in. The command is only queued here, the writes
+// to the connections happen in the background.
+func (inst *Instance) Propagate(args []string) {
+	if inst.Role() != "master" {
+		return
+	}
+
+	msg := encode.EncodeArray(args)
+
+	inst.ReplMutex.Lock()
+	defer inst.ReplMutex.Unlock()
+
+	inst.offset += int64(len(msg))
+	for _, r := range inst.replicas {
+		r.queue(msg)
+	}
+}
+
 func (inst *Instance) IncrementACK() {
 	inst.ackMtx.Lock()
 	inst.numAck++
@@ -61,14 +140,15 @@ func (inst *Instance) SetAckCnt(cnt int) {
 	inst.numAck = cnt
 }
 
+// SendReplAck asks all replicas for their offset. The request is queued
+// like propagated commands, so it arrives after them.
 func (inst *Instance) SendReplAck() {
-	repls := inst.GetReplicas()
-	for _, conn := range repls {
-		msg := []byte("*3\r\n$8\r\nreplconf\r\n$6\r\nGETACK\r\n$1\r\n*\r\n")
-		_, err := conn.Write(msg)
-
-		if err != nil {
-			fmt.Printf("Error sending REPLCONF GETACK * to replicas\n")
-		}
+	msg := []byte("*3\r\n$8\r\nreplconf\r\n$6\r\nGETACK\r\n$1\r\n*\r\n")
+
+	inst.ReplMutex.Lock()
+	defer inst.ReplMutex.Unlock()
+
+	for _, r := range inst.replicas {
+		r.queue(msg)
 	}
 }
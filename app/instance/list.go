@@ -0,0 +1,323 @@
+package instance
+
+import (
+	"slices"
+)
+
+// listChunkSize is the maximum number of elements in a chunk of a List.
+const listChunkSize = 128
+
+type listNode struct {
+	prev, next *listNode
+	elems      []string
+}
+
+// List is a list of strings, stored as a doubly linked list of chunks like
+// the quicklist of Redis. Pushing and popping at either end is O(1), access
+// by index walks over chunks instead of single elements.
+//
+// Indexes passed to List methods have to be in range, commands resolve
+// negative indexes before.
+type List struct {
+	head, tail *listNode
+	len        int
+}
+
+func NewList() *List {
+	return &List{}
+}
+
+func (l *List) Len() int {
+	return l.len
+}
+
+// insertNode links n after prev, or at the front if prev is nil.
+func (l *List) insertNode(prev *listNode, n *listNode) {
+	n.prev = prev
+
+	if prev == nil {
+		n.next = l.head
+		l.head = n
+	} else {
+		n.next = prev.next
+		prev.next = n
+	}
+
+	if n.next == nil {
+		l.tail = n
+	} else {
+		n.next.prev = n
+	}
+}
+
+func (l *List) unlinkNode(n *listNode) {
+	if n.prev == nil {
+		l.head = n.next
+	} else {
+		n.prev.next = n.next
+	}
+
+	if n.next == nil {
+		l.tail = n.prev
+	} else {
+		n.next.prev = n.prev
+	}
+}
+
+func (l *List) PushFront(elem string) {
+	if l.head == nil || len(l.head.elems) >= listChunkSize {
+		l.insertNode(nil, &listNode{})
+	}
+
+	l.head.elems = slices.Insert(l.head.elems, 0, elem)
+	l.len++
+}
+
+func (l *List) PushBack(elem string) {
+	if l.tail == nil || len(l.tail.elems) >= listChunkSize {
+		l.insertNode(l.tail, &listNode{})
+	}
+
+	l.tail.elems = append(l.tail.elems, elem)
+	l.len++
+}
+
+func (l *List) PopFront() (string, bool) {
+	if l.len == 0 {
+		return "", false
+	}
+
+	n := l.head
+	elem := n.elems[0]
+	n.elems = slices.Delete(n.elems, 0, 1)
+	if len(n.elems) == 0 {
+		l.unlinkNode(n)
+	}
+
+	l.len--
+	return elem, true
+}
+
+func (l *List) PopBack() (string, bool) {
+	if l.len == 0 {
+		return "", false
+	}
+
+	n := l.tail
+	elem := n.elems[len(n.elems)-1]
+	n.elems = slices.Delete(n.elems, len(n.elems)-1, len(n.elems))
+	if len(n.elems) == 0 {
+		l.unlinkNode(n)
+	}
+
+	l.len--
+	return elem, true
+}
+
+// locate returns the chunk holding the element at index i and the position
+// of the element in it. It walks from the nearer end of the list.
+func (l *List) locate(i int) (*listNode, int) {
+	if i < l.len/2 {
+		n := l.head
+		for i >= len(n.elems) {
+			i -= len(n.elems)
+			n = n.next
+		}
+		return n, i
+	}
+
+	n := l.tail
+	i = l.len - 1 - i
+	for i >= len(n.elems) {
+		i -= len(n.elems)
+		n = n.prev
+	}
+	return n, len(n.elems) - 1 - i
+}
+
+func (l *List) Index(i int) string {
+	n, j := l.locate(i)
+	return n.elems[j]
+}
+
+func (l *List) Set(i int, elem string) {
+	n, j := l.locate(i)
+	n.elems[j] = elem
+}
+
+// Insert inserts elem at index i, moving the elements from i on back by one.
+// An index of Len appends elem.
+func (l *List) Insert(i int, elem string) {
+	if i == 0 {
+		l.PushFront(elem)
+		return
+	}
+	if i == l.len {
+		l.PushBack(elem)
+		return
+	}
+
+	n, j := l.locate(i)
+
+	// Full chunks are split in half first
+	if len(n.elems) >= listChunkSize {
+		half := len(n.elems) / 2
+		next := &listNode{elems: slices.Clone(n.elems[half:])}
+		n.elems = slices.Delete(n.elems, half, len(n.elems))
+		l.insertNode(n, next)
+
+		if j >= half {
+			n, j = next, j-half
+		}
+	}
+
+	n.elems = slices.Insert(n.elems, j, elem)
+	l.len++
+}
+
+// Range calls fn for the elements from index start to end, both inclusive,
+// until fn returns false.
+func (l *List) Range(start int, end int, fn func(i int, elem string) bool) {
+	if start > end {
+		return
+	}
+
+	n, j := l.locate(start)
+	for i := start; i <= end; i++ {
+		if j == len(n.elems) {
+			n, j = n.next, 0
+		}
+
+		if !fn(i, n.elems[j]) {
+			return
+		}
+		j++
+	}
+}
+
+// ReverseRange is like Range, but goes from end back to start.
+func (l *List) ReverseRange(start int, end int, fn func(i int, elem string) bool) {
+	if start > end {
+		return
+	}
+
+	n, j := l.locate(end)
+	for i := end; i >= start; i-- {
+		if j < 0 {
+			n = n.prev
+			j = len(n.elems) - 1
+		}
+
+		if !fn(i, n.elems[j]) {
+			return
+		}
+		j--
+	}
+}
+
+// Remove removes up to count occurrences of elem, or all of them if count
+// is 0. With a negative count, the occurrences are removed starting at the
+// back. It returns the number of removed elements.
+func (l *List) Remove(elem string, count int) int {
+	limit := count
+	if limit < 0 {
+		limit = -limit
+	}
+
+	removed := 0
+	keep := func(e string) bool {
+		if e != elem || (limit > 0 && removed == limit) {
+			return true
+		}
+		removed++
+		return false
+	}
+
+	n := l.head
+	if count < 0 {
+		n = l.tail
+	}
+
+	for n != nil && (limit == 0 || removed < limit) {
+		var elems []string
+		if count < 0 {
+			// Filter back to front, so the last occurrences go first
+			for i := len(n.elems) - 1; i >= 0; i-- {
+				if keep(n.elems[i]) {
+					elems = append(elems, n.elems[i])
+				}
+			}
+			slices.Reverse(elems)
+		} else {
+			elems = slices.DeleteFunc(n.elems, func(e string) bool { return !keep(e) })
+		}
+
+		n.elems = elems
+		next := n.next
+		if count < 0 {
+			next = n.prev
+		}
+
+		if len(n.elems) == 0 {
+			l.unlinkNode(n)
+		}
+		n = next
+	}
+
+	l.len -= removed
+	return removed
+}
+
+// Trim keeps the elements from index start to end, both inclusive, and
+// removes all others. If start is greater than end, the list ends up empty.
+func (l *List) Trim(start int, end int) {
+	if start > end {
+		l.head, l.tail, l.len = nil, nil, 0
+		return
+	}
+
+	l.dropFront(start)
+	l.dropBack(l.len - (end - start + 1))
+}
+
+// dropFront removes the first n elements, whole chunks at once.
+func (l *List) dropFront(n int) {
+	l.len -= n
+
+	for n > 0 {
+		node := l.head
+		if n < len(node.elems) {
+			node.elems = slices.Delete(node.elems, 0, n)
+			return
+		}
+
+		n -= len(node.elems)
+		l.unlinkNode(node)
+	}
+}
+
+// dropBack removes the last n elements, whole chunks at once.
+func (l *List) dropBack(n int) {
+	l.len -= n
+
+	for n > 0 {
+		node := l.tail
+		if n < len(node.elems) {
+			node.elems = slices.Delete(node.elems, len(node.elems)-n, len(node.elems))
+			return
+		}
+
+		n -= len(node.elems)
+		l.unlinkNode(node)
+	}
+}
+
+// Clone returns a deep copy of the list.
+func (l *List) Clone() *List {
+	c := NewList()
+	for n := l.head; n != nil; n = n.next {
+		c.insertNode(c.tail, &listNode{elems: slices.Clone(n.elems)})
+	}
+	c.len = l.len
+	return c
+}
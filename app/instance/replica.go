@@ -0,0 +1,78 @@
+package instance
+
+import (
+	"fmt"
+	"net"
+	"sync"
+)
+
+// replicaBufferLimit is the amount of pending output after which a replica
+// is disconnected, the hard limit of client-output-buffer-limit of Redis.
+const replicaBufferLimit = 256 * 1024 * 1024
+
+// replica is a connected replica. Commands are queued in its buffer and
+// written to the connection by a goroutine of its own, so that a slow
+// replica does not hold up the clients writing on the master.
+type replica struct {
+	conn   net.Conn
+	mu     sync.Mutex
+	buf    []byte
+	closed bool
+	wake   chan struct{}
+}
+
+func newReplica(conn net.Conn) *replica {
+	return &replica{conn: conn, wake: make(chan struct{}, 1)}
+}
+
+// queue appends msg to the output of the replica. A replica that falls too
+// far behind is disconnected.
+func (r *replica) queue(msg []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return
+	}
+
+	if len(r.buf)+len(msg) > replicaBufferLimit {
+		fmt.Printf("Disconnecting replica %s, its output buffer is full\n", r.conn.RemoteAddr())
+		r.closed = true
+		r.conn.Close()
+	} else {
+		r.buf = append(r.buf, msg...)
+	}
+
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run writes the queued output to the connection until the replica is
+// disconnected, and then removes it from inst.
+func (r *replica) run(inst *Instance) {
+	defer inst.removeReplica(r)
+
+	var buf []byte
+	for range r.wake {
+		r.mu.Lock()
+		buf, r.buf = r.buf, buf[:0]
+		closed := r.closed
+		r.mu.Unlock()
+
+		if closed {
+			return
+		}
+
+		if _, err := r.conn.Write(buf); err != nil {
+			fmt.Printf("Error propagating to replica %s: %s\n", r.conn.RemoteAddr(), err.Error())
+
+			r.mu.Lock()
+			r.closed = true
+			r.buf = nil
+			r.mu.Unlock()
+			return
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package instance
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+)
+
+// setMaxIntsetEntries is the limit of the intset encoding of sets, as
+// set-max-intset-entries of Redis.
+const setMaxIntsetEntries = 512
+
+// Set is an unordered set of strings. Small sets whose members are all
+// integers are stored as a sorted slice of integers, like the intset
+// encoding of Redis, other sets as a slice of members along with a map from
+// each member to its index, so that random members are picked in O(1). A
+// set converts to the map encoding once a member is not an integer or it
+// has more than setMaxIntsetEntries members, and never converts back.
+type Set struct {
+	ints    []int64
+	members []string
+	m       map[string]int
+}
+
+func NewSet() *Set {
+	return &Set{}
+}
+
+// parseSetInt returns the integer member represents, if it is the canonical
+// representation of one.
+func parseSetInt(member string) (int64, bool) {
+	n, err := strconv.ParseInt(member, 10, 64)
+	if err != nil || strconv.FormatInt(n, 10) != member {
+		return 0, false
+	}
+	return n, true
+}
+
+func (s *Set) Len() int {
+	if s.m != nil {
+		return len(s.members)
+	}
+	return len(s.ints)
+}
+
+func (s *Set) Contains(member string) bool {
+	if s.m != nil {
+		_, ok := s.m[member]
+		return ok
+	}
+
+	n, ok := parseSetInt(member)
+	if !ok {
+		return false
+	}
+
+	_, found := slices.BinarySearch(s.ints, n)
+	return found
+}
+
+// Add adds member and reports whether it is new.
+func (s *Set) Add(member string) bool {
+	if s.m == nil {
+		n, ok := parseSetInt(member)
+		if !ok {
+			s.convert()
+		} else {
+			i, found := slices.BinarySearch(s.ints, n)
+			if found {
+				return false
+			}
+
+			s.ints = slices.Insert(s.ints, i, n)
+			if len(s.ints) > setMaxIntsetEntries {
+				s.convert()
+			}
+			return true
+		}
+	}
+
+	if _, ok := s.m[member]; ok {
+		return false
+	}
+	s.m[member] = len(s.members)
+	s.members = append(s.members, member)
+	return true
+}
+
+// convert switches to the map encoding.
+func (s *Set) convert() {
+	s.m = make(map[string]int, len(s.ints))
+	s.members = make([]string, 0, len(s.ints))
+	for _, n := range s.ints {
+		member := strconv.FormatInt(n, 10)
+		s.m[member] = len(s.members)
+		s.members = append(s.members, member)
+	}
+	s.ints = nil
+}
+
+// Remove removes member and reports whether it existed.
+func (s *Set) Remove(member string) bool {
+	if s.m != nil {
+		i, ok := s.m[member]
+		if !ok {
+			return false
+		}
+
+		// Move the last member into the gap
+		last := s.members[len(s.members)-1]
+		s.members[i] = last
+		s.m[last] = i
+		s.members = s.members[:len(s.members)-1]
+		delete(s.m, member)
+		return true
+	}
+
+	n, ok := parseSetInt(member)
+	if !ok {
+		return false
+	}
+
+	i, found := slices.BinarySearch(s.ints, n)
+	if found {
+		s.ints = slices.Delete(s.ints, i, i+1)
+	}
+	return found
+}
+
+// Range calls fn for all members until fn returns false. Sets in the intset
+// encoding are iterated in ascending order.
+func (s *Set) Range(fn func(member string) bool) {
+	if s.m != nil {
+		for _, member := range s.members {
+			if !fn(member) {
+				return
+			}
+		}
+		return
+	}
+
+	for _, n := range s.ints {
+		if !fn(strconv.FormatInt(n, 10)) {
+			return
+		}
+	}
+}
+
+// Members returns all members.
+func (s *Set) Members() []string {
+	members := make([]string, 0, s.Len())
+	s.Range(func(member string) bool {
+		members = append(members, member)
+		return true
+	})
+	return members
+}
+
+// Random returns a uniformly chosen member of the non-empty set.
+func (s *Set) Random() string {
+	if s.m != nil {
+		return s.members[rand.IntN(len(s.members))]
+	}
+	return strconv.FormatInt(s.ints[rand.IntN(len(s.ints))], 10)
+}
+
+// Clone returns a deep copy of the set.
+func (s *Set) Clone() *Set {
+	return &Set{ints: slices.Clone(s.ints), members: slices.Clone(s.members), m: maps.Clone(s.m)}
+}
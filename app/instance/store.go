@@ -1,52 +1,299 @@
 package instance
 
 import (
+	"hash/maphash"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// Kind is the data type of a stored value.
+type Kind int
+
+const (
+	KindString Kind = iota
+	KindList
+	KindHash
+	KindSet
+	KindZSet
+)
+
+// String returns the name of the kind as reported by TYPE.
+func (k Kind) String() string {
+	switch k {
+	case KindString:
+		return "string"
+	case KindList:
+		return "list"
+	case KindHash:
+		return "hash"
+	case KindSet:
+		return "set"
+	case KindZSet:
+		return "zset"
+	}
+	return "unknown"
+}
+
+// Value is a stored value. Kind tells which of the fields holds the data,
+// the others are unset. ExpireAt is the point in time the value expires at,
+// it is zero for values that never expire.
+//
+// Values of kinds other than strings are modified in place, which is only
+// allowed inside Store.Update.
 type Value struct {
-	Value      string
-	InsertTime time.Time
-	Expiry     *time.Duration
+	Kind     Kind
+	Value    string
+	List     *List
+	Hash     *Hash
+	Set      *Set
+	ZSet     *SortedSet
+	ExpireAt time.Time
 }
 
+// Clone returns a copy of v that does not share data with it.
+func (v Value) Clone() Value {
+	if v.List != nil {
+		v.List = v.List.Clone()
+	}
+	if v.Hash != nil {
+		v.Hash = v.Hash.Clone()
+	}
+	if v.Set != nil {
+		v.Set = v.Set.Clone()
+	}
+	if v.ZSet != nil {
+		v.ZSet = v.ZSet.Clone()
+	}
+	return v
+}
+
+// HasExpiry reports whether the value has a time to live.
+func (v Value) HasExpiry() bool {
+	return !v.ExpireAt.IsZero()
+}
+
+// Expired reports whether the value has expired at the given time.
+func (v Value) Expired(now time.Time) bool {
+	return v.HasExpiry() && !now.Before(v.ExpireAt)
+}
+
+// expires reports whether the value or any part of it has an expiry, which
+// is what the expiry index of a shard tracks.
+func (v Value) expires() bool {
+	return v.HasExpiry() || (v.Kind == KindHash && v.Hash.hasExpiringFields())
+}
+
+// SetOptions controls a conditional write by Store.Set.
+type SetOptions struct {
+	ExpireAt time.Time // Expire at the given time, or never if zero
+	KeepTTL  bool      // Keep the expiry of an existing value instead
+	NX       bool      // Only write if the key does not exist
+	XX       bool      // Only write if the key exists
+	Get      bool      // Only write if an existing value is a string
+}
+
+// ExpireCondition restricts when Store.Expire changes the expiry of a key.
+// Conditions can be combined, a zero condition always applies.
+type ExpireCondition int
+
+const (
+	ExpireNX ExpireCondition = 1 << iota // Only if the key has no expiry
+	ExpireXX                             // Only if the key has an expiry
+	ExpireGT                             // Only if the new expiry is later
+	ExpireLT                             // Only if the new expiry is earlier
+)
+
+// allows reports whether the condition allows changing the expiry from
+// current, which is zero for no expiry, to at. No expiry counts as expiring
+// never for ExpireGT and ExpireLT.
+func (cond ExpireCondition) allows(current time.Time, at time.Time) bool {
+	has := !current.IsZero()
+
+	if cond&ExpireNX != 0 && has {
+		return false
+	}
+	if cond&ExpireXX != 0 && !has {
+		return false
+	}
+	if cond&ExpireGT != 0 && (!has || !at.After(current)) {
+		return false
+	}
+	if cond&ExpireLT != 0 && has && !at.Before(current) {
+		return false
+	}
+	return true
+}
+
+// Store holds the keyspace. It is partitioned into shards by the hash of
+// the key, each with its own lock, so clients working on different keys do
+// not contend. Keys with an expiry, including hashes with expiring fields,
+// are additionally tracked in an index per shard, which the active expire
+// cycle samples from.
+//
+// All access goes through Update and View, which run a function with the
+// shards of the given keys locked. Commands propagate their writes from
+// within Update, so that replicas receive writes to a key in the order they
+// were applied.
+//
+// Expired keys are deleted when they are accessed or sampled. OnExpire is
+// called for every such key with the shard locked, so that the deletion is
+// propagated before any later write to the key. If KeepExpired is set,
+// expired keys are hidden but left in place, as a replica waits for its
+// master to delete them.
+//
+// Hash fields with an expiry are handled the same way: expired fields are
+// hidden from readers and deleted on the next write access or by the active
+// expire cycle, which calls OnExpireFields for them. A hash whose last field
+// expires is deleted without calling OnExpire, as deleting the fields
+// already deletes the key.
 type Store struct {
-	Mutex sync.RWMutex
-	Store map[string]Value
+	OnExpire       func(key string)
+	OnExpireFields func(key string, fields []string)
+	KeepExpired    bool
+
+	seed          maphash.Seed
+	shards        []shard
+	expiredKeys   atomic.Int64
+	expiredFields atomic.Int64
+}
+
+const numShards = 64
+
+type shard struct {
+	mu      sync.RWMutex
+	data    map[string]Value
+	expires map[string]struct{}
+}
+
+func NewStore() *Store {
+	return newStore(numShards)
 }
 
-func (s *Store) Write(key string, value string, expiry *time.Duration) {
-	s.Mutex.Lock()
-	s.Store[key] = Value{
-		Value:      value,
-		InsertTime: time.Now(),
-		Expiry:     expiry,
+// newStore returns a store with the given number of shards, which the
+// benchmarks vary.
+func newStore(shards int) *Store {
+	s := &Store{seed: maphash.MakeSeed(), shards: make([]shard, shards)}
+	for i := range s.shards {
+		s.shards[i].data = make(map[string]Value)
+		s.shards[i].expires = make(map[string]struct{})
 	}
-	s.Mutex.Unlock()
+	return s
+}
+
+// Get returns the value of key, including its expiry.
+func (s *Store) Get(key string) (Value, bool) {
+	var v Value
+	var ok bool
+
+	s.View([]string{key}, func(tx *Tx) error {
+		v, ok = tx.Get(key)
+		return nil
+	})
+	return v, ok
 }
 
 func (s *Store) Contains(key string) bool {
-	s.Mutex.Lock()
-	v, ok := s.Store[key]
-	s.Mutex.Unlock()
+	_, ok := s.Get(key)
+	return ok
+}
 
-	if v.Expiry == nil {
-		return ok
-	} else {
-		expiryTime := v.InsertTime.Add(*v.Expiry)
-		return ok && time.Now().Before(expiryTime)
+// Set writes value according to opts. It returns the previous value if the
+// key existed, and whether the value was written.
+func (tx *Tx) Set(key string, value string, opts SetOptions) (Value, bool, bool) {
+	prev, ok := tx.Get(key)
+
+	if (opts.NX && ok) || (opts.XX && !ok) || (opts.Get && ok && prev.Kind != KindString) {
+		return prev, ok, false
 	}
+
+	v := Value{Value: value, ExpireAt: opts.ExpireAt}
+	if opts.KeepTTL && ok {
+		v.ExpireAt = prev.ExpireAt
+	}
+
+	tx.Put(key, v)
+	return prev, ok, true
 }
 
-func (s *Store) Read(key string) (string, bool) {
-	contains := s.Contains(key)
+// Rename moves the value of src to dst, keeping its expiry. With nx set, an
+// existing dst is not overwritten. It returns whether src exists and whether
+// the value was moved.
+func (tx *Tx) Rename(src string, dst string, nx bool) (bool, bool) {
+	v, ok := tx.Get(src)
+	if !ok {
+		return false, false
+	}
+
+	if _, exists := tx.Get(dst); nx && exists {
+		return true, false
+	}
+
+	tx.Delete(src)
+	tx.Put(dst, v)
+	return true, true
+}
 
-	if !contains {
-		return "", false
+// Copy copies the value of src to dst, including its expiry. Unless replace
+// is set, an existing dst is not overwritten. It returns whether the value
+// was copied.
+func (tx *Tx) Copy(src string, dst string, replace bool) bool {
+	v, ok := tx.Get(src)
+	if !ok {
+		return false
 	}
 
-	v, ok := s.Store[key]
+	if _, exists := tx.Get(dst); exists && !replace {
+		return false
+	}
+
+	tx.Put(dst, v.Clone())
+	return true
+}
+
+// Expire sets the point in time key expires at, if cond allows it. A key
+// without expiry counts as expiring never for ExpireGT and ExpireLT. Setting
+// an expiry in the past deletes the key. It returns whether the key exists
+// and whether its expiry was changed.
+func (tx *Tx) Expire(key string, expireAt time.Time, cond ExpireCondition) (bool, bool) {
+	v, ok := tx.Get(key)
+	if !ok {
+		return false, false
+	}
+
+	if !cond.allows(v.ExpireAt, expireAt) {
+		return true, false
+	}
+
+	if !expireAt.After(tx.Now()) {
+		tx.Delete(key)
+		return true, true
+	}
+
+	v.ExpireAt = expireAt
+	tx.Put(key, v)
+	return true, true
+}
+
+// Persist removes the expiry of key and returns whether it had one.
+func (tx *Tx) Persist(key string) bool {
+	v, ok := tx.Get(key)
+	if !ok || !v.HasExpiry() {
+		return false
+	}
+
+	v.ExpireAt = time.Time{}
+	tx.Put(key, v)
+	return true
+}
+
+// ExpiredKeys returns the number of keys deleted because they expired.
+func (s *Store) ExpiredKeys() int64 {
+	return s.expiredKeys.Load()
+}
 
-	return v.Value, ok
+// ExpiredFields returns the number of hash fields deleted because they
+// expired.
+func (s *Store) ExpiredFields() int64 {
+	return s.expiredFields.Load()
 }
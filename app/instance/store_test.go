@@ -0,0 +1,63 @@
+package instance
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+// The benchmarks run GET and SET from parallel clients against stores with
+// different numbers of shards, a single shard locks like an unpartitioned
+// store. Compare how throughput scales with cores using, for example:
+//
+//	go test -run '^$' -bench Store -cpu 1,2,4,8 ./instance
+var benchShards = []int{1, 8, numShards}
+
+const benchKeys = 10000
+
+func benchmarkStore(b *testing.B, op func(s *Store, key string)) {
+	keys := make([]string, benchKeys)
+	for i := range keys {
+		keys[i] = "key:" + strconv.Itoa(i)
+	}
+
+	for _, n := range benchShards {
+		b.Run(fmt.Sprintf("shards=%d", n), func(b *testing.B) {
+			s := newStore(n)
+			for _, key := range keys {
+				s.Update([]string{key}, func(tx *Tx) error {
+					tx.Put(key, Value{Value: "value"})
+					return nil
+				})
+			}
+
+			// Every client starts at a different key
+			var next atomic.Int64
+			b.ResetTimer()
+
+			b.RunParallel(func(pb *testing.PB) {
+				i := int(next.Add(7919))
+				for pb.Next() {
+					op(s, keys[i%len(keys)])
+					i++
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkStoreGet(b *testing.B) {
+	benchmarkStore(b, func(s *Store, key string) {
+		s.Get(key)
+	})
+}
+
+func BenchmarkStoreSet(b *testing.B) {
+	benchmarkStore(b, func(s *Store, key string) {
+		s.Update([]string{key}, func(tx *Tx) error {
+			tx.Set(key, "value", SetOptions{})
+			return nil
+		})
+	})
+}
@@ -0,0 +1,232 @@
+package instance
+
+import (
+	"hash/maphash"
+	"slices"
+	"time"
+)
+
+// Tx gives access to the store inside Update and View. It must not be used
+// after the function returns, and only the keys passed to Update or View
+// may be accessed.
+type Tx struct {
+	s      *Store
+	now    time.Time
+	write  bool
+	shards []int // Locked shards in ascending order
+	stale  []string
+}
+
+// Now returns the time expiry is checked against during the transaction.
+func (tx *Tx) Now() time.Time {
+	return tx.now
+}
+
+// shard returns the shard of key, which has to be locked by the transaction.
+func (tx *Tx) shard(key string) *shard {
+	i := tx.s.shardIndex(key)
+	if _, ok := slices.BinarySearch(tx.shards, i); !ok {
+		panic("instance: access to key " + key + " outside of its transaction")
+	}
+	return &tx.s.shards[i]
+}
+
+// Get returns the value of key unless it has expired.
+func (tx *Tx) Get(key string) (Value, bool) {
+	sh := tx.shard(key)
+
+	v, ok := sh.data[key]
+	if !ok {
+		return Value{}, false
+	}
+
+	if v.Expired(tx.now) {
+		if tx.s.KeepExpired {
+			return Value{}, false
+		}
+
+		if tx.write {
+			tx.s.expire(sh, key)
+		} else {
+			tx.stale = append(tx.stale, key)
+		}
+		return Value{}, false
+	}
+
+	if v.Kind == KindHash && v.Hash.hasExpiredFields(tx.now) {
+		return tx.getExpiredFields(sh, key, v)
+	}
+
+	return v, true
+}
+
+// getExpiredFields returns the hash v stored at key without its expired
+// fields, and whether any fields are left. Readers get a copy, as they must
+// not modify the hash.
+func (tx *Tx) getExpiredFields(sh *shard, key string, v Value) (Value, bool) {
+	switch {
+	case tx.write && tx.s.KeepExpired:
+		// Writes on a replica come from the master, which deleted the
+		// fields before
+		return v, true
+
+	case tx.write:
+		tx.s.expireFields(sh, key, tx.now)
+		v, ok := sh.data[key]
+		return v, ok
+	}
+
+	if !tx.s.KeepExpired {
+		tx.stale = append(tx.stale, key)
+	}
+
+	v.Hash = v.Hash.Clone()
+	v.Hash.expireFields(tx.now)
+	return v, v.Hash.Len() > 0
+}
+
+// Put stores v under key, replacing any previous value.
+func (tx *Tx) Put(key string, v Value) {
+	if !tx.write {
+		panic("instance: Put in read only transaction")
+	}
+	tx.shard(key).put(key, v)
+}
+
+// Delete removes key and returns whether it existed.
+func (tx *Tx) Delete(key string) bool {
+	if !tx.write {
+		panic("instance: Delete in read only transaction")
+	}
+
+	_, ok := tx.Get(key)
+	tx.shard(key).remove(key)
+	return ok
+}
+
+func (s *Store) shardIndex(key string) int {
+	return int(maphash.String(s.seed, key) % uint64(len(s.shards)))
+}
+
+// shardsOf returns the distinct shards of keys in ascending order, which is
+// the order they are locked in to avoid deadlocks.
+func (s *Store) shardsOf(keys []string) []int {
+	shards := make([]int, 0, len(keys))
+	for _, key := range keys {
+		shards = append(shards, s.shardIndex(key))
+	}
+
+	slices.Sort(shards)
+	return slices.Compact(shards)
+}
+
+// Update runs fn with exclusive access to keys and returns its error.
+// Changes made before fn fails are kept.
+func (s *Store) Update(keys []string, fn func(tx *Tx) error) error {
+	shards := s.shardsOf(keys)
+
+	for _, i := range shards {
+		s.shards[i].mu.Lock()
+	}
+	defer func() {
+		for j := len(shards) - 1; j >= 0; j-- {
+			s.shards[shards[j]].mu.Unlock()
+		}
+	}()
+
+	return fn(&Tx{s: s, now: time.Now(), write: true, shards: shards})
+}
+
+// View runs fn with read access to keys and returns its error. Expired keys
+// found by fn are deleted afterwards.
+func (s *Store) View(keys []string, fn func(tx *Tx) error) error {
+	tx := &Tx{s: s, now: time.Now(), shards: s.shardsOf(keys)}
+
+	for _, i := range tx.shards {
+		s.shards[i].mu.RLock()
+	}
+
+	err := fn(tx)
+
+	for j := len(tx.shards) - 1; j >= 0; j-- {
+		s.shards[tx.shards[j]].mu.RUnlock()
+	}
+
+	for _, key := range tx.stale {
+		sh := &s.shards[s.shardIndex(key)]
+
+		// The key may have been written in the meantime
+		sh.mu.Lock()
+		s.expireDue(sh, key, time.Now())
+		sh.mu.Unlock()
+	}
+
+	return err
+}
+
+// put stores v under key and keeps the expiry index up to date, the caller
+// has to hold the lock.
+func (sh *shard) put(key string, v Value) {
+	sh.data[key] = v
+
+	if v.expires() {
+		sh.expires[key] = struct{}{}
+	} else {
+		delete(sh.expires, key)
+	}
+}
+
+// remove deletes key, the caller has to hold the lock.
+func (sh *shard) remove(key string) {
+	delete(sh.data, key)
+	delete(sh.expires, key)
+}
+
+// expire deletes the expired key and reports the deletion, the caller has to
+// hold the lock of its shard.
+func (s *Store) expire(sh *shard, key string) {
+	sh.remove(key)
+	s.expiredKeys.Add(1)
+
+	if s.OnExpire != nil {
+		s.OnExpire(key)
+	}
+}
+
+// expireFields deletes the expired fields of the hash stored at key and
+// reports the deletion, the caller has to hold the lock of its shard. The
+// hash is deleted if no fields are left.
+func (s *Store) expireFields(sh *shard, key string, now time.Time) {
+	v := sh.data[key]
+	fields := v.Hash.expireFields(now)
+	s.expiredFields.Add(int64(len(fields)))
+
+	if v.Hash.Len() == 0 {
+		sh.remove(key)
+	} else {
+		sh.put(key, v)
+	}
+
+	if s.OnExpireFields != nil {
+		s.OnExpireFields(key, fields)
+	}
+}
+
+// expireDue deletes key if it has expired, or else the expired fields of the
+// hash stored at it. It reports whether anything was deleted, the caller has
+// to hold the lock of the shard of key.
+func (s *Store) expireDue(sh *shard, key string, now time.Time) bool {
+	v, ok := sh.data[key]
+
+	switch {
+	case !ok:
+		return false
+	case v.Expired(now):
+		s.expire(sh, key)
+	case v.Kind == KindHash && v.Hash.hasExpiredFields(now):
+		s.expireFields(sh, key, now)
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,339 @@
+package instance
+
+import (
+	"math/rand/v2"
+	"strings"
+)
+
+// Parameters of the skiplist of sorted sets, the same as in Redis: every
+// level holds about a quarter of the nodes of the level below.
+const (
+	zsetMaxLevel = 32
+	zsetP        = 0.25
+)
+
+type zsetLevel struct {
+	forward *zsetNode
+	span    int // Number of nodes skipped by forward
+}
+
+type zsetNode struct {
+	member   string
+	score    float64
+	backward *zsetNode
+	level    []zsetLevel
+}
+
+// less reports whether n sorts before the given score and member. Members
+// with the same score sort lexicographically.
+func (n *zsetNode) less(score float64, member string) bool {
+	return n.score < score || (n.score == score && n.member < member)
+}
+
+// SortedSet is a set of members ordered by score, stored as a skiplist like
+// the one of Redis, plus a map from members to their scores. The skiplist
+// keeps the span of every link, so ranks are found in O(log N).
+//
+// Ranks passed to SortedSet methods have to be in range, commands resolve
+// negative ranks before.
+type SortedSet struct {
+	dict   map[string]float64
+	header *zsetNode
+	tail   *zsetNode
+	level  int
+}
+
+func NewSortedSet() *SortedSet {
+	return &SortedSet{
+		dict:   make(map[string]float64),
+		header: &zsetNode{level: make([]zsetLevel, zsetMaxLevel)},
+		level:  1,
+	}
+}
+
+func (z *SortedSet) Len() int {
+	return len(z.dict)
+}
+
+// Score returns the score of member.
+func (z *SortedSet) Score(member string) (float64, bool) {
+	score, ok := z.dict[member]
+	return score, ok
+}
+
+// Add sets the score of member and reports whether the member is new.
+func (z *SortedSet) Add(member string, score float64) bool {
+	old, exists := z.dict[member]
+	if exists {
+		if old == score {
+			return false
+		}
+		z.delete(member, old)
+	}
+
+	z.dict[member] = score
+	z.insert(member, score)
+	return !exists
+}
+
+// Remove removes member and reports whether it existed.
+func (z *SortedSet) Remove(member string) bool {
+	score, ok := z.dict[member]
+	if !ok {
+		return false
+	}
+
+	delete(z.dict, member)
+	z.delete(member, score)
+	return true
+}
+
+func randomLevel() int {
+	level := 1
+	for level < zsetMaxLevel && rand.Float64() < zsetP {
+		level++
+	}
+	return level
+}
+
+func (z *SortedSet) insert(member string, score float64) {
+	var update [zsetMaxLevel]*zsetNode
+	var rank [zsetMaxLevel]int
+
+	// Find the predecessor of the new node on each level and its rank
+	x := z.header
+	for i := z.level - 1; i >= 0; i-- {
+		if i < z.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for x.level[i].forward != nil && x.level[i].forward.less(score, member) {
+			rank[i] += x.level[i].span
+			x = x.level[i].forward
+		}
+		update[i] = x
+	}
+
+	level := randomLevel()
+	if level > z.level {
+		// The member is in dict already, so the skiplist has one node less
+		for i := z.level; i < level; i++ {
+			rank[i] = 0
+			update[i] = z.header
+			update[i].level[i].span = z.Len() - 1
+		}
+		z.level = level
+	}
+
+	x = &zsetNode{member: member, score: score, level: make([]zsetLevel, level)}
+	for i := range level {
+		x.level[i].forward = update[i].level[i].forward
+		update[i].level[i].forward = x
+
+		x.level[i].span = update[i].level[i].span - (rank[0] - rank[i])
+		update[i].level[i].span = rank[0] - rank[i] + 1
+	}
+
+	// Levels above the new node skip one more node now
+	for i := level; i < z.level; i++ {
+		update[i].level[i].span++
+	}
+
+	if update[0] != z.header {
+		x.backward = update[0]
+	}
+	if x.level[0].forward != nil {
+		x.level[0].forward.backward = x
+	} else {
+		z.tail = x
+	}
+}
+
+// delete unlinks the node of member with the given score. The member has to
+// be removed from dict separately.
+func (z *SortedSet) delete(member string, score float64) {
+	var update [zsetMaxLevel]*zsetNode
+
+	x := z.header
+	for i := z.level - 1; i >= 0; i-- {
+		for x.level[i].forward != nil && x.level[i].forward.less(score, member) {
+			x = x.level[i].forward
+		}
+		update[i] = x
+	}
+
+	x = x.level[0].forward
+	for i := range z.level {
+		if update[i].level[i].forward == x {
+			update[i].level[i].span += x.level[i].span - 1
+			update[i].level[i].forward = x.level[i].forward
+		} else {
+			update[i].level[i].span--
+		}
+	}
+
+	if x.level[0].forward != nil {
+		x.level[0].forward.backward = x.backward
+	} else {
+		z.tail = x.backward
+	}
+
+	for z.level > 1 && z.header.level[z.level-1].forward == nil {
+		z.level--
+	}
+}
+
+// Rank returns the rank of member, counting from 0 for the lowest score.
+func (z *SortedSet) Rank(member string) (int, bool) {
+	score, ok := z.dict[member]
+	if !ok {
+		return 0, false
+	}
+
+	// Count the nodes before the member
+	return z.seek(func(n *zsetNode) bool { return !n.less(score, member) }), true
+}
+
+// seek returns the rank of the first node for which pred holds, or Len if
+// there is none. pred has to be false for a prefix of the nodes and true for
+// the rest.
+func (z *SortedSet) seek(pred func(n *zsetNode) bool) int {
+	rank := 0
+
+	x := z.header
+	for i := z.level - 1; i >= 0; i-- {
+		for x.level[i].forward != nil && !pred(x.level[i].forward) {
+			rank += x.level[i].span
+			x = x.level[i].forward
+		}
+	}
+	return rank
+}
+
+// nodeAt returns the node with the given rank.
+func (z *SortedSet) nodeAt(rank int) *zsetNode {
+	traversed := -1
+
+	x := z.header
+	for i := z.level - 1; i >= 0; i-- {
+		for x.level[i].forward != nil && traversed+x.level[i].span <= rank {
+			traversed += x.level[i].span
+			x = x.level[i].forward
+		}
+		if traversed == rank {
+			return x
+		}
+	}
+	return nil
+}
+
+// Range calls fn for the members from rank start to end, both inclusive, in
+// ascending order until fn returns false.
+func (z *SortedSet) Range(start int, end int, fn func(member string, score float64) bool) {
+	if start > end {
+		return
+	}
+
+	x := z.nodeAt(start)
+	for i := start; i <= end; i++ {
+		if !fn(x.member, x.score) {
+			return
+		}
+		x = x.level[0].forward
+	}
+}
+
+// ReverseRange is like Range, but goes from end back to start.
+func (z *SortedSet) ReverseRange(start int, end int, fn func(member string, score float64) bool) {
+	if start > end {
+		return
+	}
+
+	x := z.nodeAt(end)
+	for i := end; i >= start; i-- {
+		if !fn(x.member, x.score) {
+			return
+		}
+		x = x.backward
+	}
+}
+
+// RemoveRange removes the members from rank start to end, both inclusive,
+// and returns them.
+func (z *SortedSet) RemoveRange(start int, end int) []string {
+	var members []string
+	z.Range(start, end, func(member string, _ float64) bool {
+		members = append(members, member)
+		return true
+	})
+
+	for _, member := range members {
+		z.Remove(member)
+	}
+	return members
+}
+
+// ScoreRange is an interval of scores, each bound is either inclusive or
+// exclusive.
+type ScoreRange struct {
+	Min, Max                   float64
+	MinExclusive, MaxExclusive bool
+}
+
+// Ranks returns the ranks of the members of z within r as the half open
+// interval [start, end).
+func (r ScoreRange) Ranks(z *SortedSet) (int, int) {
+	start := z.seek(func(n *zsetNode) bool {
+		return n.score > r.Min || (!r.MinExclusive && n.score == r.Min)
+	})
+	end := z.seek(func(n *zsetNode) bool {
+		return n.score > r.Max || (r.MaxExclusive && n.score == r.Max)
+	})
+	return start, max(start, end)
+}
+
+// LexBound is a bound of a LexRange. Inf is -1 for the lowest possible
+// member and 1 for the highest one, in which case Value is ignored.
+type LexBound struct {
+	Value     string
+	Exclusive bool
+	Inf       int
+}
+
+// compare compares member to the bound, as strings.Compare does, treating
+// an exclusive bound as lying between the bound value and its neighbors.
+// bias is returned if member equals the value of an exclusive bound.
+func (b LexBound) compare(member string, bias int) int {
+	if b.Inf != 0 {
+		return -b.Inf
+	}
+
+	c := strings.Compare(member, b.Value)
+	if c == 0 && b.Exclusive {
+		return bias
+	}
+	return c
+}
+
+// LexRange is an interval of members, which is only meaningful if all
+// members of the sorted set have the same score.
+type LexRange struct {
+	Min, Max LexBound
+}
+
+// Ranks returns the ranks of the members of z within r as the half open
+// interval [start, end).
+func (r LexRange) Ranks(z *SortedSet) (int, int) {
+	start := z.seek(func(n *zsetNode) bool { return r.Min.compare(n.member, -1) >= 0 })
+	end := z.seek(func(n *zsetNode) bool { return r.Max.compare(n.member, 1) > 0 })
+	return start, max(start, end)
+}
+
+// Clone returns a deep copy of the sorted set.
+func (z *SortedSet) Clone() *SortedSet {
+	c := NewSortedSet()
+	z.Range(0, z.Len()-1, func(member string, score float64) bool {
+		c.Add(member, score)
+		return true
+	})
+	return c
+}
@@ -0,0 +1,115 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SplitArgs splits a line into arguments the way redis-cli and the inline
+// protocol do. Arguments in double quotes may contain the escape sequences
+// \n, \r, \t, \b, \a, \\, \" and \xHH, arguments in single quotes only \'.
+// A closing quote has to be followed by whitespace or the end of the line.
+func SplitArgs(line string) ([]string, error) {
+	var args []string
+
+	i := 0
+	for {
+		// Skip blanks
+		for i < len(line) && isSpace(line[i]) {
+			i++
+		}
+
+		if i == len(line) {
+			return args, nil
+		}
+
+		var arg strings.Builder
+		inDouble, inSingle := false, false
+
+		for done := false; !done; {
+			if inDouble {
+				if i == len(line) {
+					return nil, fmt.Errorf("unbalanced quotes")
+				}
+
+				c := line[i]
+				if c == '\\' && i+3 < len(line) && line[i+1] == 'x' && isHex(line[i+2]) && isHex(line[i+3]) {
+					b, _ := strconv.ParseUint(line[i+2:i+4], 16, 8)
+					arg.WriteByte(byte(b))
+					i += 3
+				} else if c == '\\' && i+1 < len(line) {
+					i++
+					switch line[i] {
+					case 'n':
+						arg.WriteByte('\n')
+					case 'r':
+						arg.WriteByte('\r')
+					case 't':
+						arg.WriteByte('\t')
+					case 'b':
+						arg.WriteByte('\b')
+					case 'a':
+						arg.WriteByte('\a')
+					default:
+						arg.WriteByte(line[i])
+					}
+				} else if c == '"' {
+					// Closing quote must be followed by a space or nothing
+					if i+1 < len(line) && !isSpace(line[i+1]) {
+						return nil, fmt.Errorf("unbalanced quotes")
+					}
+					done = true
+				} else {
+					arg.WriteByte(c)
+				}
+			} else if inSingle {
+				if i == len(line) {
+					return nil, fmt.Errorf("unbalanced quotes")
+				}
+
+				c := line[i]
+				if c == '\\' && i+1 < len(line) && line[i+1] == '\'' {
+					arg.WriteByte('\'')
+					i++
+				} else if c == '\'' {
+					if i+1 < len(line) && !isSpace(line[i+1]) {
+						return nil, fmt.Errorf("unbalanced quotes")
+					}
+					done = true
+				} else {
+					arg.WriteByte(c)
+				}
+			} else {
+				if i == len(line) {
+					break
+				}
+
+				switch c := line[i]; {
+				case isSpace(c):
+					done = true
+				case c == '"':
+					inDouble = true
+				case c == '\'':
+					inSingle = true
+				default:
+					arg.WriteByte(c)
+				}
+			}
+
+			if i < len(line) {
+				i++
+			}
+		}
+
+		args = append(args, arg.String())
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
@@ -1,98 +1,272 @@
 package parser
 
 import (
-	"bufio"
 	"fmt"
-	"io"
+	"math"
+	"math/big"
 	"strconv"
-	"strings"
+
+	"github.com/codecrafters-io/redis-starter-go/app/encode"
 )
 
-const (
-	Error  = '-'
-	Status = '+'
-	Int    = ':'
-	Bulk   = '$'
-	Array  = '*'
+// Limits protecting the server against misbehaving clients. They can be
+// changed on startup by the --proto-max-bulk-len and --max-multibulk-len
+// flags.
+var (
+	MaxBulkLen      = 512 * 1024 * 1024
+	MaxMultibulkLen = 1024 * 1024
+	MaxInlineLen    = 64 * 1024
 )
 
-type Type byte
+// ProtocolError is returned for input that violates the protocol. The
+// stream can not be resynchronized afterwards, so the connection has to be
+// closed.
+type ProtocolError struct {
+	Msg string
+}
 
-type Message struct {
-	Raw  string
-	Data []string
+func (e *ProtocolError) Error() string {
+	return "Protocol error: " + e.Msg
 }
 
-func PeekMsgType(tokens []string) Type {
-	return Type(tokens[0][0])
+func protocolErrorf(format string, args ...any) error {
+	return &ProtocolError{Msg: fmt.Sprintf(format, args...)}
 }
 
-func ParseArray(msgType Type, cur string, reader *bufio.Reader) (string, []string) {
-	n, err := strconv.Atoi(cur[1 : len(cur)-2])
+type Type byte
 
-	if err != nil {
-		fmt.Printf("Error parsing array length: %s\n", err.Error())
-		return "", nil
-	}
+const (
+	Error  Type = '-'
+	Status Type = '+'
+	Int    Type = ':'
+	Bulk   Type = '$'
+	Array  Type = '*'
 
-	var msg []string
-	raw := cur
+	// RESP3 types
+	Null      Type = '_'
+	Boolean   Type = '#'
+	Double    Type = ','
+	BigNumber Type = '('
+	BulkError Type = '!'
+	Verbatim  Type = '='
+	Map       Type = '%'
+	Set       Type = '~'
+	Attribute Type = '|'
+	Push      Type = '>'
+)
 
-	i := 0
-	for i < n {
-		data, err := reader.ReadString('\n')
+// Value is a single parsed RESP value. Depending on Type, the payload is
+// stored in Str (status, errors, bulk, verbatim, big number), Int (integer),
+// Float (double), Bool (boolean) or Array (array, set, push). Maps store
+// their keys and values alternating in Array. Null bulk strings, null arrays
+// and the RESP3 null have Null set.
+type Value struct {
+	Type   Type
+	Str    string
+	Int    int64
+	Float  float64
+	Bool   bool
+	Array  []Value
+	Null   bool
+	Format string  // Encoding of a verbatim string, e.g. "txt"
+	Attrs  []Value // Attributes sent ahead of the value, as key value pairs
+}
 
-		if err != nil {
-			fmt.Printf("Error reading for array: %s\n", err.Error())
-			continue
+// String returns the textual payload of the value, numbers and booleans are
+// formatted the way they are sent on the wire.
+func (v Value) String() string {
+	switch v.Type {
+	case Int:
+		return strconv.FormatInt(v.Int, 10)
+	case Double:
+		return encode.FormatDouble(v.Float)
+	case Boolean:
+		if v.Bool {
+			return "t"
 		}
+		return "f"
+	}
+	return v.Str
+}
+
+// IsError reports whether the value is an error reply.
+func (v Value) IsError() bool {
+	return v.Type == Error || v.Type == BulkError
+}
+
+// IsNull reports whether the value is a null bulk string or null array.
+func (v Value) IsNull() bool {
+	return v.Null
+}
 
-		r, m := ParseMsg(data, reader)
-		raw = raw + r
-		msg = append(msg, m...)
+// Strings flattens the value into a list of strings. Nested arrays are
+// flattened depth first, null values are skipped.
+func (v Value) Strings() []string {
+	if v.Null {
+		return nil
+	}
 
-		i++
+	if !v.IsAggregate() {
+		return []string{v.String()}
 	}
 
-	return raw, msg
+	var strs []string
+	for _, e := range v.Array {
+		strs = append(strs, e.Strings()...)
+	}
+	return strs
+}
+
+// IsAggregate reports whether the value holds nested values.
+func (v Value) IsAggregate() bool {
+	switch v.Type {
+	case Array, Map, Set, Push:
+		return true
+	}
+	return false
+}
+
+// Message is a command read from a connection. Size is the number of bytes
+// the command took on the wire, which is needed to track replication
+// offsets. Err is set if the connection sent invalid data instead.
+type Message struct {
+	Args []string
+	Size int
+	Err  error
 }
 
-func ParseMsg(cur string, reader *bufio.Reader) (string, []string) {
-	msgType := Type(cur[0])
+// ReadValue reads the next complete value of any type. Unlike ReadCommand,
+// the returned value does not reference the internal buffer.
+func (r *Reader) ReadValue() (Value, error) {
+	line, err := r.readLine()
+
+	if err != nil {
+		return Value{}, err
+	}
+
+	if len(line) == 0 {
+		return Value{}, protocolErrorf("empty line")
+	}
+
+	msgType := Type(line[0])
+	payload := string(line[1:])
+
 	switch msgType {
-	case Error, Status, Int:
-		return cur, []string{cur[1 : len(cur)-2]}
-	case Bulk:
-		n, err := strconv.Atoi(cur[1 : len(cur)-2])
+	case Error, Status:
+		return Value{Type: msgType, Str: payload}, nil
+	case Int:
+		n, err := strconv.ParseInt(payload, 10, 64)
+
+		if err != nil {
+			return Value{}, protocolErrorf("invalid integer %s", strconv.Quote(payload))
+		}
+
+		return Value{Type: msgType, Int: n}, nil
+	case Null:
+		return Value{Type: msgType, Null: true}, nil
+	case Boolean:
+		if payload != "t" && payload != "f" {
+			return Value{}, protocolErrorf("invalid boolean %s", strconv.Quote(payload))
+		}
+
+		return Value{Type: msgType, Bool: payload == "t"}, nil
+	case Double:
+		f, err := parseDouble(payload)
 
 		if err != nil {
-			fmt.Printf("Error reading for length of bulk string: %s\n", err.Error())
-			return "", nil
+			return Value{}, protocolErrorf("invalid double %s", strconv.Quote(payload))
 		}
 
-		// Read n bytes from connection
-		buf := make([]byte, n)
-		n, err = io.ReadFull(reader, buf)
+		return Value{Type: msgType, Float: f}, nil
+	case BigNumber:
+		if _, ok := new(big.Int).SetString(payload, 10); !ok {
+			return Value{}, protocolErrorf("invalid big number %s", strconv.Quote(payload))
+		}
+
+		return Value{Type: msgType, Str: payload}, nil
+	case Bulk, BulkError, Verbatim:
+		n, err := strconv.Atoi(payload)
+
+		if err != nil || n > MaxBulkLen {
+			return Value{}, protocolErrorf("invalid bulk length")
+		}
+
+		if n < 0 {
+			return Value{Type: msgType, Null: true}, nil
+		}
+
+		buf, err := r.readN(n + 2)
 
 		if err != nil {
-			fmt.Printf("Could not read bulk string: %s\n", err.Error())
-			return "", nil
+			return Value{}, err
+		}
+
+		val := Value{Type: msgType, Str: string(buf[:n])}
+
+		// Verbatim strings are prefixed by their three letter format
+		if msgType == Verbatim {
+			if n < 4 || val.Str[3] != ':' {
+				return Value{}, protocolErrorf("invalid verbatim string")
+			}
+			val.Format, val.Str = val.Str[:3], val.Str[4:]
+		}
+
+		return val, nil
+	case Array, Map, Set, Push, Attribute:
+		n, err := strconv.Atoi(payload)
+
+		if err != nil || n > MaxMultibulkLen {
+			return Value{}, protocolErrorf("invalid multibulk length")
+		}
+
+		if n < 0 {
+			return Value{Type: msgType, Null: true}, nil
+		}
+
+		// Maps and attributes are sent as n key value pairs
+		if msgType == Map || msgType == Attribute {
+			n *= 2
 		}
 
-		// If the next two bytes are \r\n, read it and discard it
-		var tmp []byte
-		tmp, err = reader.Peek(2)
-		if err == nil && tmp[0] == '\r' && tmp[1] == '\n' {
-			reader.Read(tmp)
+		// Don't trust the length for preallocation, the elements might never come
+		val := Value{Type: msgType, Array: make([]Value, 0, min(n, 1024))}
+
+		for i := 0; i < n; i++ {
+			e, err := r.ReadValue()
+
+			if err != nil {
+				return Value{}, err
+			}
+
+			val.Array = append(val.Array, e)
+		}
 
-			buf = append(buf, '\r', '\n')
-			n += 2
+		if msgType != Attribute {
+			return val, nil
 		}
 
-		raw := strings.Join([]string{cur, string(buf[:n])}, "")
-		return raw, []string{string(buf[:n-2])}
-	case Array:
-		return ParseArray(msgType, cur, reader)
+		// Attributes are followed by the value they describe
+		described, err := r.ReadValue()
+
+		if err != nil {
+			return Value{}, err
+		}
+
+		described.Attrs = val.Array
+		return described, nil
+	}
+
+	return Value{}, protocolErrorf("unexpected type byte %s", strconv.QuoteRune(rune(msgType)))
+}
+
+func parseDouble(str string) (float64, error) {
+	switch str {
+	case "inf", "+inf":
+		return math.Inf(1), nil
+	case "-inf":
+		return math.Inf(-1), nil
+	case "nan":
+		return math.NaN(), nil
 	}
-	return "", nil
+	return strconv.ParseFloat(str, 64)
 }
@@ -0,0 +1,293 @@
+package parser
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+)
+
+const (
+	defaultBufSize = 16 * 1024
+	maxIdleBufSize = 64 * 1024
+)
+
+// span locates an argument relative to the start of the current command.
+type span struct {
+	start, end int
+}
+
+// Reader reads RESP messages from a connection. Commands are parsed in
+// place, the arguments returned by ReadCommand point into the internal
+// buffer and are only valid until the next read.
+//
+// A command which has not fully arrived yet is parsed incrementally, so
+// large pipelines and bulk strings are not rescanned on every read.
+type Reader struct {
+	rd     io.Reader
+	buf    []byte
+	r, w   int   // buf[r:w] holds the unconsumed input
+	offset int64 // Number of bytes consumed since the start
+
+	// Parse state of a partially received multibulk command. All positions
+	// are relative to r, which stays put until the command is complete.
+	started bool
+	n       int
+	pos     int
+	need    int
+	spans   []span
+	args    [][]byte
+}
+
+func NewReader(rd io.Reader) *Reader {
+	return &Reader{rd: rd, buf: make([]byte, defaultBufSize)}
+}
+
+// Offset returns the number of bytes consumed from the connection.
+func (r *Reader) Offset() int64 {
+	return r.offset
+}
+
+// Buffered returns the number of bytes that have been read from the
+// connection but not yet consumed.
+func (r *Reader) Buffered() int {
+	return r.w - r.r
+}
+
+// ReadCommand reads the next command, either a multibulk request or an
+// inline command. An empty request results in no arguments.
+func (r *Reader) ReadCommand() ([][]byte, error) {
+	// Drop a buffer grown by a huge request once it is no longer needed
+	if r.r == r.w && len(r.buf) > maxIdleBufSize {
+		r.buf = make([]byte, defaultBufSize)
+		r.r, r.w = 0, 0
+	}
+
+	for {
+		var done bool
+		var err error
+
+		if r.r < r.w && r.buf[r.r] != byte(Array) {
+			done, err = r.parseInline()
+		} else if r.r < r.w {
+			done, err = r.parseMultibulk()
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if done {
+			return r.args, nil
+		}
+
+		if err := r.fill(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (r *Reader) parseInline() (bool, error) {
+	i := bytes.IndexByte(r.buf[r.r:r.w], '\n')
+
+	if i < 0 {
+		if r.w-r.r > MaxInlineLen {
+			return false, protocolErrorf("too big inline request")
+		}
+		return false, nil
+	}
+
+	line := bytes.TrimRight(r.buf[r.r:r.r+i], "\r")
+
+	args, err := SplitArgs(string(line))
+	if err != nil {
+		return false, protocolErrorf("%s in request", err.Error())
+	}
+
+	r.args = r.args[:0]
+	for _, arg := range args {
+		r.args = append(r.args, []byte(arg))
+	}
+
+	r.advance(i + 1)
+	return true, nil
+}
+
+func (r *Reader) parseMultibulk() (bool, error) {
+	data := r.buf[r.r:r.w]
+
+	if !r.started {
+		line, ok, err := r.header(data, "mbulk count")
+
+		if !ok || err != nil {
+			return false, err
+		}
+
+		n, err := strconv.Atoi(string(line[1:]))
+		if err != nil || n > MaxMultibulkLen {
+			return false, protocolErrorf("invalid multibulk length")
+		}
+
+		r.started = true
+		r.n = max(n, 0)
+		r.pos = len(line) + 2
+		r.spans = r.spans[:0]
+	}
+
+	for len(r.spans) < r.n {
+		// The header of the bulk string is only consumed together with
+		// its payload, so pos always points at a header
+		line, ok, err := r.header(data, "bulk count")
+
+		if !ok || err != nil {
+			return false, err
+		}
+
+		if line[0] != byte(Bulk) {
+			return false, protocolErrorf("expected '$', got '%c'", line[0])
+		}
+
+		n, err := strconv.Atoi(string(line[1:]))
+		if err != nil || n < 0 || n > MaxBulkLen {
+			return false, protocolErrorf("invalid bulk length")
+		}
+
+		start := r.pos + len(line) + 2
+		end := start + n
+
+		if end+2 > len(data) {
+			r.need = end + 2
+			return false, nil
+		}
+
+		r.spans = append(r.spans, span{start, end})
+		r.pos = end + 2
+	}
+
+	r.args = r.args[:0]
+	for _, s := range r.spans {
+		r.args = append(r.args, data[s.start:s.end])
+	}
+
+	r.advance(r.pos)
+	return true, nil
+}
+
+// header returns the line at pos without its line ending. If the line is
+// incomplete, ok is false.
+func (r *Reader) header(data []byte, what string) ([]byte, bool, error) {
+	i := bytes.IndexByte(data[r.pos:], '\n')
+
+	if i < 0 {
+		if len(data)-r.pos > MaxInlineLen {
+			return nil, false, protocolErrorf("too big %s string", what)
+		}
+		return nil, false, nil
+	}
+
+	line := data[r.pos : r.pos+i]
+	if len(line) < 2 || line[len(line)-1] != '\r' {
+		return nil, false, protocolErrorf("invalid %s line", what)
+	}
+
+	return line[:len(line)-1], true, nil
+}
+
+// advance consumes n bytes and resets the parse state.
+func (r *Reader) advance(n int) {
+	r.r += n
+	r.offset += int64(n)
+	r.started = false
+	r.pos = 0
+	r.need = 0
+}
+
+// fill reads more data from the connection, making room in the buffer if
+// needed. Positions relative to r stay valid.
+func (r *Reader) fill() error {
+	if r.r > 0 {
+		copy(r.buf, r.buf[r.r:r.w])
+		r.w -= r.r
+		r.r = 0
+	}
+
+	if r.w == len(r.buf) || r.need > len(r.buf) {
+		buf := make([]byte, max(2*len(r.buf), r.need))
+		copy(buf, r.buf[:r.w])
+		r.buf = buf
+	}
+
+	for {
+		n, err := r.rd.Read(r.buf[r.w:])
+		r.w += n
+
+		if n > 0 {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// readLine returns the next line without its line ending.
+func (r *Reader) readLine() ([]byte, error) {
+	for {
+		i := bytes.IndexByte(r.buf[r.r:r.w], '\n')
+
+		if i >= 0 {
+			line := bytes.TrimRight(r.buf[r.r:r.r+i], "\r")
+			r.advance(i + 1)
+			return line, nil
+		}
+
+		if r.w-r.r > MaxInlineLen {
+			return nil, protocolErrorf("too big inline request")
+		}
+
+		if err := r.fill(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// readN returns the next n bytes.
+func (r *Reader) readN(n int) ([]byte, error) {
+	for r.w-r.r < n {
+		r.need = n
+
+		if err := r.fill(); err != nil {
+			return nil, err
+		}
+	}
+
+	buf := r.buf[r.r : r.r+n]
+	r.advance(n)
+	return buf, nil
+}
+
+// ReadRDB reads the database dump sent after FULLRESYNC. It is framed like
+// a bulk string, but without a trailing \r\n.
+func (r *Reader) ReadRDB() ([]byte, error) {
+	line, err := r.readLine()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(line) == 0 || line[0] != byte(Bulk) {
+		return nil, protocolErrorf("expected RDB file")
+	}
+
+	n, err := strconv.Atoi(string(line[1:]))
+	if err != nil || n < 0 {
+		return nil, protocolErrorf("invalid bulk length")
+	}
+
+	buf, err := r.readN(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.Clone(buf), nil
+}
@@ -0,0 +1,220 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// The benchmarks compare ReadCommand, which parses commands in place, with
+// ReadValue, which builds a value tree and copies every bulk string like the
+// parser ReadCommand replaced. Input arrives in chunks of the size of a TCP
+// segment, so commands are split across reads as they are on a connection.
+
+const benchChunkSize = 1460
+
+// chunkReader returns at most benchChunkSize bytes per read.
+type chunkReader struct {
+	data []byte
+}
+
+func (c *chunkReader) Read(p []byte) (int, error) {
+	if len(c.data) == 0 {
+		return 0, io.EOF
+	}
+
+	n := copy(p[:min(len(p), benchChunkSize)], c.data)
+	c.data = c.data[n:]
+	return n, nil
+}
+
+func command(args ...string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	return b.String()
+}
+
+func TestReadCommand(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"multibulk", "*2\r\n$3\r\nGET\r\n$3\r\nkey\r\n", []string{"GET", "key"}},
+		{"empty bulk", "*2\r\n$4\r\nECHO\r\n$0\r\n\r\n", []string{"ECHO", ""}},
+		{"binary bulk", "*2\r\n$4\r\nECHO\r\n$4\r\na\r\nb\r\n", []string{"ECHO", "a\r\nb"}},
+		{"empty multibulk", "*0\r\n", []string{}},
+		{"negative multibulk", "*-1\r\n", []string{}},
+		{"inline", "SET key value\r\n", []string{"SET", "key", "value"}},
+		{"inline without \\r", "PING\n", []string{"PING"}},
+		{"inline quoted", "SET key \"a b\\n\"\r\n", []string{"SET", "key", "a b\n"}},
+		{"empty line", "\r\n", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Byte by byte reads split the command at every position
+			for _, rd := range []io.Reader{strings.NewReader(tt.input), iotest.OneByteReader(strings.NewReader(tt.input))} {
+				r := NewReader(rd)
+
+				args, err := r.ReadCommand()
+				if err != nil {
+					t.Fatalf("ReadCommand() error = %v", err)
+				}
+
+				got := make([]string, len(args))
+				for i, arg := range args {
+					got[i] = string(arg)
+				}
+				if !slices.Equal(got, tt.want) {
+					t.Errorf("ReadCommand() = %q, want %q", got, tt.want)
+				}
+				if r.Offset() != int64(len(tt.input)) {
+					t.Errorf("Offset() = %d, want %d", r.Offset(), len(tt.input))
+				}
+			}
+		})
+	}
+}
+
+func TestReadCommandProtocolError(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"invalid multibulk length", "*x\r\n"},
+		{"too long multibulk", "*" + strconv.Itoa(MaxMultibulkLen+1) + "\r\n"},
+		{"invalid bulk length", "*1\r\n$x\r\n"},
+		{"negative bulk length", "*1\r\n$-1\r\n"},
+		{"missing bulk", "*1\r\n+OK\r\n"},
+		{"header without \\r", "*1\n$4\r\nPING\r\n"},
+		{"unbalanced quotes", "SET key \"value\r\n"},
+		{"too big inline", strings.Repeat("x", MaxInlineLen+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReader(strings.NewReader(tt.input))
+
+			_, err := r.ReadCommand()
+			var perr *ProtocolError
+			if !errors.As(err, &perr) {
+				t.Errorf("ReadCommand() error = %v, want a protocol error", err)
+			}
+		})
+	}
+}
+
+func TestReadCommandPipeline(t *testing.T) {
+	value := strings.Repeat("x", 100*1024)
+	cmds := []string{
+		command("SET", "key", value),
+		"PING\r\n",
+		command("GET", "key"),
+	}
+	r := NewReader(&chunkReader{data: []byte(strings.Join(cmds, ""))})
+
+	var offset int64
+	for i, want := range [][]string{{"SET", "key", value}, {"PING"}, {"GET", "key"}} {
+		args, err := r.ReadCommand()
+		if err != nil {
+			t.Fatalf("command %d: ReadCommand() error = %v", i, err)
+		}
+
+		got := make([]string, len(args))
+		for i, arg := range args {
+			got[i] = string(arg)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("command %d: ReadCommand() returned %d args, want %q", i, len(got), want[0])
+		}
+
+		offset += int64(len(cmds[i]))
+		if r.Offset() != offset {
+			t.Errorf("command %d: Offset() = %d, want %d", i, r.Offset(), offset)
+		}
+	}
+
+	if _, err := r.ReadCommand(); err != io.EOF {
+		t.Errorf("ReadCommand() at the end error = %v, want EOF", err)
+	}
+}
+
+// benchInputs are 100 pipelined MSET commands of 100 pairs each, and a SET
+// of a 4 MiB value.
+func benchInputs() map[string][]byte {
+	var mset strings.Builder
+	for i := range 100 {
+		args := []string{"MSET"}
+		for j := range 100 {
+			args = append(args, "key:"+strconv.Itoa(i*100+j), "value:"+strconv.Itoa(j))
+		}
+		mset.WriteString(command(args...))
+	}
+
+	return map[string][]byte{
+		"mset": []byte(mset.String()),
+		"bulk": []byte(command("SET", "key", strings.Repeat("x", 4<<20))),
+	}
+}
+
+func benchmarkReader(b *testing.B, read func(r *Reader) error) {
+	inputs := benchInputs()
+
+	for _, name := range []string{"mset", "bulk"} {
+		input := inputs[name]
+
+		b.Run(name, func(b *testing.B) {
+			b.SetBytes(int64(len(input)))
+			b.ReportAllocs()
+
+			for range b.N {
+				r := NewReader(&chunkReader{data: input})
+				for {
+					err := read(r)
+					if err == io.EOF {
+						break
+					}
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkReadCommand(b *testing.B) {
+	benchmarkReader(b, func(r *Reader) error {
+		_, err := r.ReadCommand()
+		return err
+	})
+}
+
+func BenchmarkReadValue(b *testing.B) {
+	benchmarkReader(b, func(r *Reader) error {
+		_, err := r.ReadValue()
+		return err
+	})
+}
+
+// BenchmarkReadCommandStrings includes copying the arguments to strings, as
+// the server does before handing them to the command layer.
+func BenchmarkReadCommandStrings(b *testing.B) {
+	benchmarkReader(b, func(r *Reader) error {
+		args, err := r.ReadCommand()
+		strs := make([]string, len(args))
+		for i, arg := range args {
+			strs[i] = string(arg)
+		}
+		return err
+	})
+}
@@ -1,14 +1,16 @@
 package main
 
 import (
-	"bufio"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/codecrafters-io/redis-starter-go/app/commands"
 	"github.com/codecrafters-io/redis-starter-go/app/encode"
@@ -62,14 +64,52 @@ func (q *ThreadSafeQueue[T]) Data() []T {
 }
 
 type Client struct {
+	ID       int64
+	Name     string
 	Conn     net.Conn
+	Out      *encode.Writer
 	MsgQueue ThreadSafeQueue[parser.Message]
 	CmdQueue ThreadSafeQueue[commands.Command]
 	ReplMode bool
+	Closed   atomic.Bool
+
+	wake chan struct{} // Signaled when a message arrives
+	done chan struct{} // Closed when the connection is closed
+}
+
+var nextClientID atomic.Int64
+
+func NewClient(conn net.Conn) *Client {
+	return &Client{
+		ID:   nextClientID.Add(1),
+		Conn: conn,
+		Out:  encode.NewWriter(conn),
+		wake: make(chan struct{}, 1),
+		done: make(chan struct{}),
+	}
 }
 
 func (c *Client) Receive(msg parser.Message) {
 	c.MsgQueue.Push(msg)
+
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Close marks the client as closed once no more messages will arrive.
+func (c *Client) Close() {
+	c.Closed.Store(true)
+	close(c.done)
+}
+
+// waitForMessage waits until a message arrives or the client is closed.
+func (c *Client) waitForMessage() {
+	select {
+	case <-c.wake:
+	case <-c.done:
+	}
 }
 
 func (c *Client) NumMessages() int {
@@ -80,75 +120,81 @@ func (c *Client) NumCommands() int {
 	return c.CmdQueue.Len()
 }
 
-func (c *Client) HandleNextMsg() (int, commands.Command) {
+// HandleNextMsg creates the command for the next message. It returns the
+// size of the message, and an error if the message is no valid command.
+// Empty messages result in neither a command nor an error.
+func (c *Client) HandleNextMsg() (int, commands.Command, error) {
 	msg := c.MsgQueue.Pop()
 
-	cmdstr := strings.ToLower(msg.Data[0])
+	if len(msg.Args) == 0 {
+		return msg.Size, nil, nil
+	}
 
-	cmd := commands.CreateCommand(cmdstr, msg.Data[1:])
+	cmd, err := commands.CreateCommand(msg.Args[0], msg.Args[1:])
 
-	if cmd != nil {
-		c.CmdQueue.Push(cmd)
-		return len(msg.Raw), cmd
+	if err != nil {
+		return msg.Size, nil, err
 	}
 
-	return 0, nil
+	c.CmdQueue.Push(cmd)
+	return msg.Size, cmd, nil
 }
 
-func (c *Client) ExecuteCommand(cmd commands.Command, inst *instance.Instance) []byte {
-	var resp []byte
-	var err error
-	if cmd != nil {
-		resp, err = cmd.Execute(inst)
+// ExecuteCommand runs cmd and writes its reply to w.
+func (c *Client) ExecuteCommand(cmd commands.Command, inst *instance.Instance, w *encode.Writer) {
+	hello, ishello := cmd.(*commands.HelloCommand)
+	if ishello {
+		hello.ClientID = c.ID
+	}
 
-		if err != nil {
-			fmt.Printf("Error executing command: %s", err.Error())
-			return nil
-		}
+	if blocking, ok := cmd.(commands.BlockingCommand); ok {
+		blocking.SetCancel(c.done)
 	}
 
-	// Forward to replicas
-	setcmd, ok := cmd.(*commands.SetCommand)
+	if cmd != nil {
+		err := cmd.Execute(inst, w)
 
-	if ok {
-		conns := inst.GetReplicas()
-		replmsg := setcmd.Encode()
-		if inst.NumReplicas() > 0 {
-			fmt.Printf("Sending %s to replicas\n", strconv.Quote(string(replmsg)))
-			for _, conn := range conns {
-				conn.Write([]byte(replmsg))
-			}
+		// Clients blocked on keys written by the command are served
+		// right away, in the order they blocked
+		inst.ServeBlocked()
+
+		if err != nil {
+			fmt.Printf("Error executing command: %s\n", err.Error())
+			commands.WriteError(w, err)
+			return
 		}
 	}
 
-	// For some other messages, we still need to do some work, even if we don't respond, or already have a responds
-	_, ok = cmd.(*commands.FullsyncCommand)
-	if ok {
-		for c.NumMessages() < 1 {
-		}
-		// Drop it, we don't deal with it
-		c.MsgQueue.Pop()
+	if ishello && hello.Name != "" {
+		c.Name = hello.Name
 	}
 
-	_, ok = cmd.(*commands.PsyncCommand)
+	// For some other messages, we still need to do some work, even if we don't respond, or already have a responds
+	_, ok := cmd.(*commands.PsyncCommand)
 	if ok {
 		fmt.Printf("Adding replica\n")
 
+		// The RDB file has to arrive before the first propagated command
+		if err := w.Flush(); err != nil {
+			fmt.Printf("Error sending RDB file to replica: %s\n", err.Error())
+			return
+		}
+
 		// Add conn to connection
 		inst.AddReplica(c.Conn)
 	}
-
-	return resp
 }
 
 func (c *Client) listenForAck(done chan struct{}, inst *instance.Instance) {
+	discard := encode.NewWriter(io.Discard)
+
 	for {
 		if c.NumMessages() > 0 {
-			_, subcmd := c.HandleNextMsg()
+			_, subcmd, _ := c.HandleNextMsg()
 
 			_, ok := subcmd.(*commands.ReplconfCommand)
 			if ok {
-				subcmd.Execute(inst)
+				subcmd.Execute(inst, discard)
 			}
 		}
 
@@ -159,78 +205,139 @@ func (c *Client) listenForAck(done chan struct{}, inst *instance.Instance) {
 	}
 }
 
-func (c *Client) Process(output chan []byte, inst *instance.Instance) {
+// Process runs the commands sent by the client. Replies are buffered and
+// flushed once all commands received so far have been handled, so a
+// pipeline is answered with a single write.
+func (c *Client) Process(inst *instance.Instance) {
 	for {
+		// Check before looking at the queue, messages are always queued
+		// before the client is marked as closed
+		closed := c.Closed.Load()
+
 		if c.NumMessages() > 0 {
-			_, cmd := c.HandleNextMsg()
+			if err := c.MsgQueue.Peek().Err; err != nil {
+				c.MsgQueue.Pop()
+				c.Out.Error("ERR " + err.Error())
+				c.Out.Flush()
+				return
+			}
+
+			_, cmd, err := c.HandleNextMsg()
 			fmt.Printf("Processing command: %v\n", cmd)
 
-			if cmd != nil {
+			if err != nil {
+				commands.WriteError(c.Out, err)
+			} else if cmd != nil {
 				_, iswait := cmd.(*commands.WaitCommand)
+				_, isblocking := cmd.(commands.BlockingCommand)
 
-				done := make(chan struct{})
-				defer close(done)
+				var done chan struct{}
 				if iswait {
+					done = make(chan struct{})
 					go c.listenForAck(done, inst)
 				}
 
-				resp := c.ExecuteCommand(cmd, inst)
+				// Don't hold back earlier replies while waiting
+				if iswait || isblocking {
+					if err := c.Out.Flush(); err != nil {
+						return
+					}
+				}
+
+				c.ExecuteCommand(cmd, inst, c.Out)
 
-				if resp != nil {
-					output <- resp
+				if done != nil {
+					close(done)
 				}
 			}
+
+			if c.NumMessages() == 0 {
+				if err := c.Out.Flush(); err != nil {
+					fmt.Printf("Error writing output: %s\n", err.Error())
+					return
+				}
+			}
+		} else if closed {
+			return
+		} else {
+			c.waitForMessage()
 		}
 	}
 }
 
-func (c *Client) ProcessMaster(output chan []byte, inst *instance.Instance) {
+func (c *Client) ProcessMaster(inst *instance.Instance) {
+	// The master only gets to see replies to REPLCONF GETACK
+	discard := encode.NewWriter(io.Discard)
+
 	for {
+		closed := c.Closed.Load()
+
 		if c.NumMessages() > 0 {
-			_, cmd := c.HandleNextMsg()
+			if err := c.MsgQueue.Peek().Err; err != nil {
+				fmt.Printf("Dropping connection to master: %s\n", err.Error())
+				return
+			}
 
-			if cmd != nil {
-				resp := c.ExecuteCommand(cmd, inst)
+			size, cmd, err := c.HandleNextMsg()
+
+			if err != nil {
+				fmt.Printf("Ignoring invalid command from master: %s\n", err.Error())
+			} else if cmd != nil {
 				replcmd, ok := cmd.(*commands.ReplconfCommand)
 
-				if resp != nil && ok && replcmd.SubCmd == "getack" {
-					output <- resp
+				if ok && replcmd.SubCmd == "getack" {
+					c.ExecuteCommand(cmd, inst, c.Out)
+					if err := c.Out.Flush(); err != nil {
+						fmt.Printf("Error writing to master: %s\n", err.Error())
+						return
+					}
+				} else {
+					c.ExecuteCommand(cmd, inst, discard)
+					discard.Flush()
 				}
 			}
+
+			// The acknowledged offset includes everything processed before
+			// GETACK, but not GETACK itself
+			inst.AddReplOffset(size)
+		} else if closed {
+			return
+		} else {
+			c.waitForMessage()
 		}
 	}
 }
 
-func asyncRead(conn net.Conn, client *Client) {
-	reader := bufio.NewReader(conn)
+func asyncRead(conn net.Conn, reader *parser.Reader, client *Client) {
+	defer client.Close()
 
 	for {
-		cur, err := reader.ReadString('\n')
-
-		if err != nil {
+		before := reader.Offset()
+		args, err := reader.ReadCommand()
+
+		var protoErr *parser.ProtocolError
+		if errors.As(err, &protoErr) {
+			// Let the client reply with the error once the messages before
+			// it have been handled
+			fmt.Printf("Protocol error from %s: %s\n", conn.RemoteAddr().String(), protoErr.Msg)
+			client.Receive(parser.Message{Err: err})
+			return
+		} else if err != nil {
 			fmt.Printf("Error reading from reader: %s\n", err.Error())
-			break
+			return
 		}
 
-		raw, data := parser.ParseMsg(cur, reader)
-
-		fmt.Printf("Receive: %s\n", strconv.Quote(raw))
-
-		var msg parser.Message
-		if raw != "" && data != nil {
-			msg = parser.Message{Raw: raw, Data: data}
-			client.Receive(msg)
+		// The arguments point into the buffer of the reader, which goes on
+		// reading while the command runs, and commands keep them as keys and
+		// values. So they are copied here, once, into strings of their own.
+		msg := parser.Message{Args: make([]string, len(args)), Size: int(reader.Offset() - before)}
+		for i, arg := range args {
+			msg.Args[i] = string(arg)
 		}
-	}
-}
 
-func asyncWrite(conn net.Conn, output chan []byte) {
-	for resp := range output {
-		_, err := conn.Write(resp)
+		fmt.Printf("Receive: %q\n", msg.Args)
 
-		if err != nil {
-			fmt.Printf("Error writing output: %s\n", err.Error())
-		}
+		client.Receive(msg)
 	}
 }
 
@@ -241,102 +348,77 @@ func eventLoop(connections chan net.Conn, inst *instance.Instance) {
 
 			fmt.Printf("Working on connection %v\n", conn.RemoteAddr().String())
 
-			output := make(chan []byte)
-
-			client := Client{Conn: conn}
+			client := NewClient(conn)
 
-			go asyncRead(conn, &client)
-			go asyncWrite(conn, output)
-			client.Process(output, inst)
+			go asyncRead(conn, parser.NewReader(conn), client)
+			client.Process(inst)
 		}()
 	}
 }
 
 func handleMaster(conn net.Conn, port string, inst *instance.Instance) {
-	client := Client{Conn: conn}
-	output := make(chan []byte)
-
-	go asyncRead(conn, &client)
-	go asyncWrite(conn, output)
+	reader := parser.NewReader(conn)
 
-	// Step 1: send ping
-	output <- []byte("*1\r\n$4\r\nPING\r\n")
-
-	// Wait for response
-	for client.NumMessages() == 0 {
-	}
-
-	msg := client.MsgQueue.Pop()
-
-	cmdstr := strings.ToLower(msg.Data[0])
-	if cmdstr != "pong" {
-		fmt.Printf("Handshake with master failed, expected pong, is %s\n", strconv.Quote(cmdstr))
-	}
-
-	// Step 2: Send REPLCONF listening-port <PORT>
-	output <- encode.EncodeArray([]string{"REPLCONF", "listening-port", port})
+	// The handshake is done synchronously, replication starts afterwards
+	handshake := func(req []string, check func(reply parser.Value) bool) {
+		_, err := conn.Write(encode.EncodeArray(req))
+		if err != nil {
+			fmt.Printf("Handshake with master failed, could not send %s: %s\n", req[0], err.Error())
+			return
+		}
 
-	// Wait for response
-	for client.NumMessages() == 0 {
-	}
+		reply, err := reader.ReadValue()
+		if err != nil {
+			fmt.Printf("Handshake with master failed, no reply to %s: %s\n", req[0], err.Error())
+			return
+		}
 
-	msg = client.MsgQueue.Pop()
-	cmdstr = strings.ToLower(msg.Data[0])
-	if cmdstr != "ok" {
-		fmt.Printf("Handshake with master failed, expected OK to REPLCONF listening-port %s\n", port)
+		if !check(reply) {
+			fmt.Printf("Handshake with master failed, unexpected reply to %q: %s\n", req, strconv.Quote(reply.String()))
+		}
 	}
 
-	output <- encode.EncodeArray([]string{"REPLCONF", "capa", "psync2"})
-
-	// Wait for response
-	for client.NumMessages() == 0 {
+	isStatus := func(status string) func(reply parser.Value) bool {
+		return func(reply parser.Value) bool {
+			return reply.Type == parser.Status && strings.HasPrefix(strings.ToLower(reply.Str), status)
+		}
 	}
 
-	msg = client.MsgQueue.Pop()
-	cmdstr = strings.ToLower(msg.Data[0])
+	// Step 1: send ping
+	handshake([]string{"PING"}, isStatus("pong"))
 
-	if cmdstr != "ok" {
-		fmt.Printf("Handshake with master failed, expected OK to REPLCONF capa psync2\n")
-	}
+	// Step 2: Send REPLCONF listening-port <PORT>
+	handshake([]string{"REPLCONF", "listening-port", port}, isStatus("ok"))
+	handshake([]string{"REPLCONF", "capa", "psync2"}, isStatus("ok"))
 
-	output <- encode.EncodeArray([]string{"PSYNC", "?", "-1"})
+	// Step 3: Request a full resync, which is followed by the RDB file
+	handshake([]string{"PSYNC", "?", "-1"}, isStatus("fullresync"))
 
-	// Wait for response
-	for client.NumMessages() == 0 {
+	_, err := reader.ReadRDB()
+	if err != nil {
+		fmt.Printf("Handshake with master failed, expected RDB file: %s\n", err.Error())
 	}
 
-	msg = client.MsgQueue.Pop()
-	cmdstr = strings.ToLower(msg.Data[0])
-	if cmdstr == "fullresync" {
-		fmt.Printf("Handshake with master failed, expected FULLRESYNC to REPLCONF capa psync2\n")
-	}
+	// Offsets count the replication stream after the RDB file
+	client := NewClient(conn)
 
-	// Wait for RDB file
-	for client.NumMessages() == 0 {
-	}
-	msg = client.MsgQueue.Pop()
+	go asyncRead(conn, reader, client)
 
-	client.ProcessMaster(output, inst)
+	client.ProcessMaster(inst)
 }
 
 func main() {
 	host := "0.0.0.0"
 	port := "6379"
 
-	inst := instance.Instance{AckChan: make(chan struct{})}
-	inst.Info = make(map[string]map[string]string)
-	inst.Info["replication"] = make(map[string]string)
-	inst.Info["replication"]["role"] = "master"
-	inst.Info["replication"]["master_replid"] = "8371b4fb1155b71f4a04d3e1bc3e18c4a990aeeb"
-	inst.Info["replication"]["master_repl_offset"] = "0"
-	inst.SetAckCnt(0)
-
-	inst.Store = instance.Store{
-		Store: make(map[string]instance.Value),
-	}
+	inst := instance.NewInstance()
+	inst.SetInfo("replication", "role", "master")
+	inst.SetInfo("replication", "master_replid", "8371b4fb1155b71f4a04d3e1bc3e18c4a990aeeb")
 
 	// Parse flags
 	port_arg_pointer := flag.String("port", port, "--port <PORT>")
+	flag.IntVar(&parser.MaxBulkLen, "proto-max-bulk-len", parser.MaxBulkLen, "--proto-max-bulk-len <BYTES>")
+	flag.IntVar(&parser.MaxMultibulkLen, "max-multibulk-len", parser.MaxMultibulkLen, "--max-multibulk-len <COUNT>")
 	primary_host_arg_pointer := flag.String("replicaof", "", "--replicaof <MASTER HOST> <MASTER PORT>")
 	flag.Parse()
 
@@ -345,13 +427,26 @@ func main() {
 		if len(master_host) > 1 {
 			master_port := flag.Arg(0)
 			if len(master_port) > 0 {
-				inst.Info["replication"]["role"] = "slave"
-				inst.Info["replication"]["port"] = master_port
-				inst.Info["replication"]["host"] = master_host
+				inst.SetInfo("replication", "role", "slave")
+				inst.SetInfo("replication", "port", master_port)
+				inst.SetInfo("replication", "host", master_host)
 			}
 		}
 	}
 
+	// Only the master expires keys, replicas follow its DEL and HDEL commands
+	if inst.Role() == "master" {
+		inst.Store.OnExpire = func(key string) {
+			inst.Propagate([]string{"DEL", key})
+		}
+		inst.Store.OnExpireFields = func(key string, fields []string) {
+			inst.Propagate(append([]string{"HDEL", key}, fields...))
+		}
+		go inst.ExpireLoop()
+	} else {
+		inst.Store.KeepExpired = true
+	}
+
 	if len(*port_arg_pointer) > 0 {
 		port = *port_arg_pointer
 	}
@@ -367,18 +462,18 @@ func main() {
 	fmt.Printf("Server is listening on port %s\n", port)
 
 	connections := make(chan net.Conn)
-	go eventLoop(connections, &inst)
+	go eventLoop(connections, inst)
 
 	// Sync if we are a slave
-	if inst.Info["replication"]["role"] == "slave" {
-		conn, err := net.Dial("tcp", net.JoinHostPort(inst.Info["replication"]["host"], inst.Info["replication"]["port"]))
+	if inst.Role() == "slave" {
+		conn, err := net.Dial("tcp", net.JoinHostPort(inst.GetInfo("replication", "host"), inst.GetInfo("replication", "port")))
 
 		if err != nil {
 			fmt.Printf("Error connection to master: %s\n", err.Error())
 			os.Exit(1)
 		}
 
-		go handleMaster(conn, port, &inst)
+		go handleMaster(conn, port, inst)
 	}
 
 	for {